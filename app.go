@@ -5,37 +5,41 @@ import (
 	"encoding/json"
 	"fmt"
 	"golang.org/x/net/html"
-	"io"
-	"net/http"
 	"net/url"
 	"os/exec"
 	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
-	"time"
+	"sync"
 )
 
 // App struct
 type App struct {
-	ctx      context.Context
-	settings AppSettings
+	ctx         context.Context
+	cancel      context.CancelFunc
+	settingsMu  sync.RWMutex
+	settings    AppSettings
+	cache       *Cache
+	inspections *inspections
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{}
+	return &App{cache: NewCache(), inspections: newInspections()}
 }
 
 // startup is called at application startup
 func (a *App) startup(ctx context.Context) {
-	// Perform your setup here
-	a.ctx = ctx
-	a.settings, _ = LoadSettings()
+	// Every request is derived from a.ctx, so cancelling it on shutdown aborts in-flight work
+	a.ctx, a.cancel = context.WithCancel(ctx)
+	settings, _ := LoadSettings()
+	a.setSettings(settings)
+	a.refreshAppCatalog()
 }
 
 // domReady is called after front-end resources have been loaded
-func (a App) domReady(ctx context.Context) {
+func (a *App) domReady(ctx context.Context) {
 	// Add your action here
 }
 
@@ -48,7 +52,12 @@ func (a *App) beforeClose(ctx context.Context) (prevent bool) {
 
 // shutdown is called at application termination
 func (a *App) shutdown(ctx context.Context) {
-	// Perform your teardown here
+	if a.cancel != nil {
+		a.cancel()
+	}
+	if err := a.cache.Close(); err != nil {
+		fmt.Printf("Error saving cache: %v", err)
+	}
 }
 
 // Greet returns a greeting for the given name
@@ -56,193 +65,141 @@ func (a *App) Greet(name string) string {
 	return fmt.Sprintf("Hello %s, It's show time!", name)
 }
 
-type Cache struct {
-	steamIDCache map[string]string
-	gameCache    map[string]string
-}
-
-func NewCache() *Cache {
-	return &Cache{
-		steamIDCache: make(map[string]string),
-		gameCache:    make(map[string]string),
-	}
-}
-
-func (c *Cache) lookupSteamID(url string) string {
-	return c.steamIDCache[url]
-}
-
-func (c *Cache) addSteamID(url string, image string, ttl int) {
-	c.steamIDCache[url] = image
-	go func() {
-		<-time.After(time.Duration(ttl) * time.Minute)
-		delete(c.steamIDCache, url)
-	}()
+func (a *App) GetAppId(url string) string {
+	return getAppIDGolang(url)
 }
 
-func (c *Cache) lookupGame(url string) string {
-	return c.gameCache[url]
+// GameName is what the app knows about an app without asking the store. Details is only set
+// when the session cache already holds the store details, GetAppDetails fetches them.
+type GameName struct {
+	SteamAppID int         `json:"steam_appid"`
+	Name       string      `json:"name"`
+	Details    *AppDetails `json:"details"`
 }
 
-func (c *Cache) addGame(url string, name string, ttl int) {
-	c.gameCache[url] = name
-	go func() {
-		<-time.After(time.Duration(ttl) * time.Minute)
-		delete(c.gameCache, url)
-	}()
+// GetGameName names the app an item image belongs to. Apps without a name come back with a
+// zero steam_appid.
+func (a *App) GetGameName(inspectionID string, url string, countryCode string) (_ GameName, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return GameName{}, err
+	}
+	appId, err := strconv.Atoi(getAppIDGolang(url))
+	if err != nil {
+		return GameName{}, nil
+	}
+	return getGameName(ctx, appId, a.appDetailsOptions(countryCode), a.cache)
 }
 
-func getImage(profileURL string, cache *Cache) (string, error) {
-	cachedResult := cache.lookupSteamID(profileURL)
-	if cachedResult != "" && cachedResult != "NO_BACKGROUND" {
-		return cachedResult, nil
+// getGameName looks the name up with getAppName and attaches the store details of options
+// when they are cached
+func getGameName(ctx context.Context, appId int, options AppDetailsOptions, cache *Cache) (GameName, error) {
+	name, err := getAppName(ctx, appId, options.Language, cache)
+	if err != nil || name == "" {
+		return GameName{}, err
 	}
-
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", profileURL, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %v", err)
+	gameName := GameName{SteamAppID: appId, Name: name}
+	if details, status := cache.games.Get(options.cacheKey(appId)); status == CacheHit {
+		gameName.Details = &details
 	}
+	return gameName, nil
+}
 
-	resp, err := client.Do(req)
+func (a *App) GetBackground(inspectionID string, url string) (_ ProfileBackground, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %v", err)
+		return ProfileBackground{}, err
 	}
-	defer resp.Body.Close()
+	return getProfileBackground(ctx, url, a.cache)
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (a *App) GetPageBodyViaGolang(inspectionID string, url string) (_ string, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %v", err)
+		return "", err
 	}
-	responseBody := string(body)
+	return getPageBodyViaGolang(ctx, url)
+}
 
-	doc, err := html.Parse(strings.NewReader(responseBody))
+func (a *App) GetSteam32IDViaGolang(inspectionID string, username string, key string) (_ string, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
-
-	var divsWithBackgroundImage []*html.Node
-	var findDivsWithBackgroundImage func(*html.Node)
-
-	findDivsWithBackgroundImage = func(n *html.Node) {
-		if n.Type == html.ElementNode && n.Data == "div" {
-			for _, a := range n.Attr {
-				if a.Key == "class" && strings.Contains(a.Val, "profile_page") && strings.Contains(a.Val, "has_profile_background") {
-					divsWithBackgroundImage = append(divsWithBackgroundImage, n)
-					break
-				}
-			}
-		}
-
-		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			findDivsWithBackgroundImage(c)
-		}
+	steamID, vanity, err := ParseProfileInput(username)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidSteamID, err)
 	}
-
-	findDivsWithBackgroundImage(doc)
-
-	for _, divWithBackgroundImage := range divsWithBackgroundImage {
-		var backgroundImageURL string
-
-		for _, a := range divWithBackgroundImage.Attr {
-			if a.Key == "style" {
-				style := strings.TrimSpace(a.Val)
-				re := regexp.MustCompile(`background-image:\s*url\(\s*'?(.*?)'\s*?\);`)
-				matches := re.FindStringSubmatch(style)
-				if len(matches) < 2 {
-					backgroundImageURL = ""
-				} else {
-					backgroundImageURL = strings.TrimSpace(matches[1])
-				}
-			}
-		}
-		if backgroundImageURL != "" {
-			cache.addSteamID(profileURL, backgroundImageURL, 7)
-			return backgroundImageURL, nil
-		} else {
-			videoSourceURL := ""
-			for profileAnimatedBackground := divWithBackgroundImage.FirstChild; profileAnimatedBackground != nil; profileAnimatedBackground = profileAnimatedBackground.NextSibling {
-				if profileAnimatedBackground.Type == html.ElementNode && profileAnimatedBackground.Data == "div" {
-					for _, a := range profileAnimatedBackground.Attr {
-						if a.Key == "class" && strings.Contains(a.Val, "profile_animated_background") {
-							for videoNode := profileAnimatedBackground.FirstChild; videoNode != nil; videoNode = videoNode.NextSibling {
-								if videoNode != nil && videoNode.Type == html.ElementNode && videoNode.Data == "video" {
-									for c := videoNode.FirstChild; c != nil; c = c.NextSibling {
-										if c.Type == html.ElementNode && c.Data == "source" {
-											for _, sourceAttr := range c.Attr {
-												if sourceAttr.Key == "type" && sourceAttr.Val == "video/mp4" {
-													videoSourceURL = c.Attr[0].Val
-													break
-												}
-											}
-										}
-									}
-								}
-							}
-						}
-					}
-
-					if videoSourceURL != "" {
-						cache.addSteamID(profileURL, videoSourceURL, 7)
-						return videoSourceURL, nil
-					}
-				}
-			}
+	if vanity != "" {
+		resolution, err := resolveVanity(ctx, vanity, key, a.cache)
+		if err != nil {
+			return "", err
 		}
+		steamID = resolution.SteamID
 	}
-
-	cache.addSteamID(profileURL, "NO_BACKGROUND", 1)
-	return "Profile has no background", nil
+	return strconv.FormatUint(uint64(steamID.AccountID()), 10), nil
 }
 
-func (a *App) GetAppId(url string) string {
-	return getAppIDGolang(url)
-}
-
-func (a *App) GetGameName(url string, cache *Cache) (string, error) {
-	return getGameName(url, cache)
-}
-
-func (a *App) GetBackground(url string, cache *Cache) (string, error) {
-	return getImage(url, cache)
+func (a *App) GetEquippedItemsViaGolang(inspectionID string, steam64ID string, language string) (_ []EquippedItem, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return nil, err
+	}
+	return GetEquippedItems(ctx, steam64ID, a.languageFor(language))
 }
 
-func (a *App) GetPageBodyViaGolang(url string) (string, error) {
-	return getPageBodyViaGolang(url)
+func (a *App) OpenCustomURLViaGolang(url string, fallbackUrl string, appName string) (err error) {
+	defer handleBindingError(&err)
+	return openCustomUrl(url, fallbackUrl, appName)
 }
 
-func (a *App) GetSteam32IDViaGolang(username string, key string) (string, error) {
-	if key != "" {
-		steam64ID, err := getSteam64IDViaAPI(username, key)
-		if err != nil {
-			if strings.Contains(err.Error(), "API key not authorized to access this resource") {
-				return "", fmt.Errorf("API key not authorized to access Steam API")
-			}
-			return getSteam32IDViaScrapper(username)
-		}
-		steam32ID, err := steam64ToSteam32(steam64ID)
-		if err != nil {
-			return getSteam32IDViaScrapper(username)
-		}
-		return steam32ID, nil
+func (a *App) AddMarketURIToEquippedItemsViaGolang(inspectionID string, items []EquippedItem, currency int) (_ []EquippedItem, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return nil, err
 	}
-	return getSteam32IDViaScrapper(username)
+	if err := validateCurrency(currency); err != nil {
+		return nil, err
+	}
+	items = addMarketURIToEquippedItems(ctx, items, a.marketOptions(currency), a.cache, a.emitMarketProgress(inspectionID))
+	return items, ctx.Err()
 }
 
-func (a *App) GetEquippedItemsViaGolang(steam64ID string, language string) ([]EquippedItem, error) {
-	return GetEquippedItems(steam64ID, language)
+func (a *App) PutMarketPriceToEquippedItemViaGolang(inspectionID string, item EquippedItem, currency int) (_ EquippedItem, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return item, err
+	}
+	if item.ItemMarketID == 0 {
+		return item, fmt.Errorf("%w: %s has no market listing", ErrNotMarketable, item.ItemName)
+	}
+	if err := validateCurrency(currency); err != nil {
+		return item, err
+	}
+	item = putMarketPriceToEquippedItem(ctx, item, currency, a.languageFor(""), a.cache)
+	return item, ctx.Err()
 }
 
-func (a *App) OpenCustomURLViaGolang(url string, fallbackUrl string, appName string) error {
-	return openCustomUrl(url, fallbackUrl, appName)
+func (a *App) marketOptions(currency int) marketOptions {
+	return marketOptions{Currency: currency, Language: a.languageFor(""), Workers: a.currentSettings().MarketWorkers}
 }
 
-func (a *App) AddMarketURIToEquippedItemsViaGolang(items []EquippedItem, currency int) []EquippedItem {
-	return addMarketURIToEquippedItems(items, currency)
+// ClearCache forgets everything cached in memory and on disk
+func (a *App) ClearCache() (err error) {
+	defer handleBindingError(&err)
+	return a.cache.Clear()
 }
 
-func (a *App) PutMarketPriceToEquippedItemViaGolang(item EquippedItem, currency int) EquippedItem {
-	return putMarketPriceToEquippedItem(item, currency)
+// GetCacheStats returns the entry count and hit, miss and eviction counters of every cache
+func (a *App) GetCacheStats() CacheStatsByStore {
+	return a.cache.Stats()
 }
 
 func openCustomUrl(url string, fallbackUrl string, appName string) error {
@@ -297,108 +254,75 @@ func openUrl(url string) error {
 	return err
 }
 
-func getSteam64IDViaAPI(username string, key string) (string, error) {
-	requestURL := fmt.Sprintf("http://api.steampowered.com/ISteamUser/ResolveVanityURL/v0001/?key=%s&vanityurl=%s", key, username)
-	resp, err := http.Get(requestURL)
+func getSteam64IDViaAPI(ctx context.Context, username string, key string) (string, error) {
+	requestURL := fmt.Sprintf("https://api.steampowered.com/ISteamUser/ResolveVanityURL/v0001/?key=%s&vanityurl=%s", key, url.QueryEscape(username))
+	resp, err := steamClient.Get(ctx, requestURL)
 	if err != nil {
 		return "", err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode == 403 {
-		return "", fmt.Errorf("API key not authorized to access this resource")
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return "", ErrInvalidAPIKey
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", &StatusError{StatusCode: resp.StatusCode, URL: redactURL(requestURL)}
 	}
 	var data map[string]interface{}
 	err = json.NewDecoder(resp.Body).Decode(&data)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("%w: %w", ErrMarkupChanged, err)
 	}
 	response, ok := data["response"].(map[string]interface{})
 	if !ok {
 		str, _ := json.Marshal(data)
-		return "", fmt.Errorf("response not found in data " + string(str))
+		return "", fmt.Errorf("%w: response not found in data %s", ErrMarkupChanged, str)
 	}
 	steamid, ok := response["steamid"].(string)
 	if !ok {
 		str, _ := json.Marshal(data)
-		return "", fmt.Errorf("SteamID not found in response " + string(str))
+		return "", fmt.Errorf("%w: SteamID not found in response %s", ErrProfileNotFound, str)
 	}
 	return steamid, nil
 }
 
-func steam64ToSteam32(steam64 string) (string, error) {
-	const steam64Base = 76561197960265728
-	id, err := strconv.ParseUint(steam64, 10, 64)
+func getSteamIDViaScrapper(ctx context.Context, vanity string) (SteamID, error) {
+	htmlString, err := getPageBodyViaGolang(ctx, "https://steamid.xyz/https://steamcommunity.com/id/"+vanity)
 	if err != nil {
-		return "", err
-	}
-	steam32 := strconv.FormatUint(id-steam64Base, 10)
-	return steam32, nil
-}
-
-func getSteam32IDViaScrapper(username string) (string, error) {
-	var profileURL string
-	if match, _ := regexp.MatchString(`^7\d{15}([02468])$`, username); match {
-		profileURL = "https://steamcommunity.com/profiles/" + username
-	} else {
-		profileURL = "https://steamcommunity.com/id/" + username
-	}
-	htmlString, err := getPageBodyViaGolang("https://steamid.xyz/" + profileURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to get page body: %v", err)
+		return 0, fmt.Errorf("failed to get page body: %w", err)
 	}
 	doc, err := html.Parse(strings.NewReader(htmlString))
 	if err != nil {
-		return "", fmt.Errorf("failed to parse html: %v", err)
+		return 0, fmt.Errorf("failed to parse html: %w: %w", ErrMarkupChanged, err)
 	}
-	steam32Regex := regexp.MustCompile(`^STEAM_0:(\d+):(\d+)$`)
-	var steam32Input *html.Node
-	var findSteam32Input func(*html.Node)
-	findSteam32Input = func(n *html.Node) {
-		if steam32Input != nil {
+	var steam2Value string
+	var findSteam2Input func(*html.Node)
+	findSteam2Input = func(n *html.Node) {
+		if steam2Value != "" {
 			return
 		}
 		if n.Type == html.ElementNode && n.Data == "input" {
 			for _, attr := range n.Attr {
 				if attr.Key == "value" && strings.HasPrefix(attr.Val, "STEAM_") {
-					steam32Input = n
+					steam2Value = attr.Val
 					return
 				}
 			}
 		}
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			findSteam32Input(c)
+			findSteam2Input(c)
 		}
 	}
-	findSteam32Input(doc)
-	if err != nil || steam32Input == nil {
-		return "", fmt.Errorf("input not found: %v", err)
-	}
-	steam32Value := ""
-	for _, attr := range steam32Input.Attr {
-		if attr.Key == "value" {
-			steam32Value = attr.Val
-			break
-		}
-	}
-	matches := steam32Regex.FindStringSubmatch(steam32Value)
-	if err != nil {
-		return "", fmt.Errorf("value not found: %v", err)
+	findSteam2Input(doc)
+	if steam2Value == "" {
+		return 0, fmt.Errorf("%w: STEAM_ id not found on steamid.xyz for %s", ErrProfileNotFound, vanity)
 	}
-	a, _ := strconv.Atoi(matches[1])
-	b, _ := strconv.Atoi(matches[2])
-	steam32ID := strconv.Itoa(b*2 + a)
-	return steam32ID, nil
+	return ParseSteamID(steam2Value)
 }
 
-func getPageBodyViaGolang(url string) (string, error) {
-	resp, err := http.Get(url)
+func getPageBodyViaGolang(ctx context.Context, url string) (string, error) {
+	body, err := steamClient.GetBody(ctx, url)
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %v", err)
-	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %v", err)
+		return "", err
 	}
 	return string(body), nil
 }
@@ -406,46 +330,31 @@ func getPageBodyViaGolang(url string) (string, error) {
 func getAppIDGolang(url string) string {
 	pattern := regexp.MustCompile(`(\d+)(?:/|\.[\da-z]+$)`)
 	matcher := pattern.FindStringSubmatch(url)
-	if len(matcher) < 1 {
+	if len(matcher) < 2 {
 		return ""
 	}
 	return matcher[1]
 }
 
-func getGameName(imageURL string, cache *Cache) (string, error) {
-	cachedResult := cache.lookupGame(imageURL)
-	if cachedResult != "" && cachedResult != "NOT_EXIST" {
-		return cachedResult, nil
+// getAppName returns the store name of appId, "" if it has none. The offline catalog only knows
+// the default names, so it answers english lookups right away and is the fallback for apps that
+// no longer have a store page.
+func getAppName(ctx context.Context, appId int, language string, cache *Cache) (string, error) {
+	catalogName, inCatalog := cache.apps.Lookup(appId)
+	if inCatalog && language == defaultLanguage {
+		return catalogName, nil
 	}
-	appId := getAppIDGolang(imageURL)
-	if appId == "" {
-		return "", nil
+	if name, status := cache.disk.Get(DiskCacheAppName, strconv.Itoa(appId)+":"+language); status == CacheHit {
+		return name, nil
 	}
-	resp, err := http.Get(fmt.Sprintf("https://store.steampowered.com/api/appdetails?appids=%s", appId))
+	details, ok, err := getCachedAppDetails(ctx, appId, AppDetailsOptions{Language: language}, cache)
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %v", err)
+		return "", err
 	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %v", err)
-	}
-	responseBody := string(body)
-	if strings.Contains(responseBody, `"success":true`) {
-		pattern := regexp.MustCompile(`name":"(.*?)"`)
-		nameMatcher := pattern.FindStringSubmatch(responseBody)
-		if len(nameMatcher) > 1 {
-			name := nameMatcher[1]
-			cache.addGame(imageURL, name, 32)
-			return name, nil
-		} else {
-			cache.addGame(imageURL, "NOT_EXIST", 7)
-			return "", nil
-		}
-	} else {
-		cache.addGame(imageURL, "NOT_EXIST", 7)
-		return "", nil
+	if !ok {
+		return catalogName, nil
 	}
+	return details.Name, nil
 }
 
 func (a *App) invalidURL(profileURL string) bool {
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// appCatalogMaxAge is how old the catalog may get before it is refreshed at startup
+const appCatalogMaxAge = 24 * time.Hour
+
+// appCatalogPageSize is max_results of IStoreService/GetAppList, the endpoint caps it at 50000
+const appCatalogPageSize = 50000
+
+type AppCatalogEntry struct {
+	Appid int    `json:"appid"`
+	Name  string `json:"name"`
+}
+
+type appCatalogFile struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	// LastModified is the newest last_modified seen, the if_modified_since of the next refresh
+	LastModified int64             `json:"last_modified"`
+	Apps         []AppCatalogEntry `json:"apps"`
+}
+
+// AppCatalog maps appids to app names without touching the network. It is persisted as
+// apps.json in the app data directory.
+type AppCatalog struct {
+	mu           sync.RWMutex
+	filename     string
+	names        map[int]string
+	search       []appCatalogSearchEntry
+	updatedAt    time.Time
+	lastModified int64
+	refreshing   sync.Mutex
+}
+
+type appCatalogSearchEntry struct {
+	appid int
+	name  string
+	// folded is the lowercased name with punctuation collapsed to single spaces
+	folded string
+}
+
+// OpenAppCatalog loads the catalog from disk. Like the disk cache it is usable even on error.
+func OpenAppCatalog() (*AppCatalog, error) {
+	dir, err := getAppdataDir()
+	if err != nil {
+		return &AppCatalog{names: make(map[int]string)}, err
+	}
+	return loadAppCatalog(filepath.Join(dir, "apps.json"))
+}
+
+func loadAppCatalog(filename string) (*AppCatalog, error) {
+	c := &AppCatalog{filename: filename, names: make(map[int]string)}
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	var file appCatalogFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return c, err
+	}
+	c.replace(file)
+	return c, nil
+}
+
+func (c *AppCatalog) replace(file appCatalogFile) {
+	names := make(map[int]string, len(file.Apps))
+	for _, app := range file.Apps {
+		if app.Name != "" {
+			names[app.Appid] = app.Name
+		}
+	}
+	search := buildAppCatalogSearch(names)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names = names
+	c.search = search
+	c.updatedAt = file.UpdatedAt
+	c.lastModified = file.LastModified
+}
+
+func buildAppCatalogSearch(names map[int]string) []appCatalogSearchEntry {
+	search := make([]appCatalogSearchEntry, 0, len(names))
+	for appid, name := range names {
+		search = append(search, appCatalogSearchEntry{appid: appid, name: name, folded: foldAppName(name)})
+	}
+	sort.Slice(search, func(i, j int) bool { return search[i].appid < search[j].appid })
+	return search
+}
+
+// foldAppName lowercases name and turns every run of non alphanumeric runes into one space
+func foldAppName(name string) string {
+	var builder strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if space && builder.Len() > 0 {
+				builder.WriteByte(' ')
+			}
+			space = false
+			builder.WriteRune(r)
+		} else {
+			space = true
+		}
+	}
+	return builder.String()
+}
+
+func (c *AppCatalog) Lookup(appid int) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	name, ok := c.names[appid]
+	return name, ok
+}
+
+func (c *AppCatalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.names)
+}
+
+func (c *AppCatalog) Stale() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.names) == 0 || time.Since(c.updatedAt) > appCatalogMaxAge
+}
+
+// Search returns up to limit apps whose name matches query, best matches first. Exact names
+// rank above prefixes, prefixes above whole words, words above substrings and substrings above
+// names that merely contain the letters of query in order.
+func (c *AppCatalog) Search(query string, limit int) []AppCatalogEntry {
+	query = foldAppName(query)
+	if query == "" || limit <= 0 {
+		return []AppCatalogEntry{}
+	}
+	type match struct {
+		entry *appCatalogSearchEntry
+		score int
+	}
+	var matches []match
+	c.mu.RLock()
+	for i := range c.search {
+		if score := fuzzyAppNameScore(c.search[i].folded, query); score > 0 {
+			matches = append(matches, match{entry: &c.search[i], score: score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if len(matches[i].entry.name) != len(matches[j].entry.name) {
+			return len(matches[i].entry.name) < len(matches[j].entry.name)
+		}
+		return matches[i].entry.appid < matches[j].entry.appid
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	results := make([]AppCatalogEntry, len(matches))
+	for i, m := range matches {
+		results[i] = AppCatalogEntry{Appid: m.entry.appid, Name: m.entry.name}
+	}
+	c.mu.RUnlock()
+	return results
+}
+
+// fuzzyAppNameScore scores how well the folded name matches the folded query, 0 means no match
+func fuzzyAppNameScore(name string, query string) int {
+	switch {
+	case name == query:
+		return 1000
+	case strings.HasPrefix(name, query):
+		return 800
+	case strings.Contains(" "+name+" ", " "+query+" "):
+		return 700
+	case strings.Contains(" "+name, " "+query):
+		return 600
+	case strings.Contains(name, query):
+		return 500
+	}
+	// Subsequence match, scored lower the more runes are skipped between matched ones
+	nameRunes := []rune(name)
+	gaps, position := 0, -1
+	for _, r := range query {
+		found := false
+		for position+1 < len(nameRunes) {
+			position++
+			if nameRunes[position] == r {
+				found = true
+				break
+			}
+			if r != ' ' {
+				gaps++
+			}
+		}
+		if !found {
+			return 0
+		}
+	}
+	if score := 400 - gaps*10; score > 0 {
+		return score
+	}
+	return 0
+}
+
+// Refresh updates the catalog. Only refreshes with an API key are incremental, they fetch the
+// apps modified since the last refresh from IStoreService/GetAppList. Without a key, or with a
+// key Steam rejects, the full ISteamApps/GetAppList is downloaded every time, that endpoint has
+// no if_modified_since.
+func (c *AppCatalog) Refresh(ctx context.Context, key string) error {
+	c.refreshing.Lock()
+	defer c.refreshing.Unlock()
+
+	c.mu.RLock()
+	names := make(map[int]string, len(c.names))
+	for appid, name := range c.names {
+		names[appid] = name
+	}
+	lastModified := c.lastModified
+	c.mu.RUnlock()
+
+	var err error
+	if key != "" {
+		lastModified, err = fetchModifiedApps(ctx, key, lastModified, names)
+	}
+	if key == "" || errors.Is(err, ErrInvalidAPIKey) {
+		names, err = fetchFullAppList(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	file := appCatalogFile{UpdatedAt: time.Now(), LastModified: lastModified, Apps: make([]AppCatalogEntry, 0, len(names))}
+	for appid, name := range names {
+		file.Apps = append(file.Apps, AppCatalogEntry{Appid: appid, Name: name})
+	}
+	sort.Slice(file.Apps, func(i, j int) bool { return file.Apps[i].Appid < file.Apps[j].Appid })
+	c.replace(file)
+	return c.save(file)
+}
+
+func (c *AppCatalog) save(file appCatalogFile) error {
+	if c.filename == "" {
+		return nil
+	}
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(c.filename), 0755)
+	if err != nil {
+		return err
+	}
+	tmp := c.filename + ".tmp"
+	err = os.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.filename)
+}
+
+// fetchFullAppList downloads every app there is, several megabytes. Stale limits it to once a day.
+func fetchFullAppList(ctx context.Context) (map[int]string, error) {
+	body, err := steamClient.GetBody(ctx, "https://api.steampowered.com/ISteamApps/GetAppList/v2/")
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		AppList struct {
+			Apps []AppCatalogEntry `json:"apps"`
+		} `json:"applist"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse app list: %w: %w", ErrMarkupChanged, err)
+	}
+	names := make(map[int]string, len(response.AppList.Apps))
+	for _, app := range response.AppList.Apps {
+		if name := strings.TrimSpace(app.Name); name != "" {
+			names[app.Appid] = name
+		}
+	}
+	return names, nil
+}
+
+// fetchModifiedApps pages through IStoreService/GetAppList and merges every app modified after
+// since into names. It returns the newest modification time it saw.
+func fetchModifiedApps(ctx context.Context, key string, since int64, names map[int]string) (int64, error) {
+	newest := since
+	lastAppid := 0
+	for {
+		query := url.Values{}
+		query.Set("key", key)
+		query.Set("max_results", strconv.Itoa(appCatalogPageSize))
+		query.Set("include_games", "true")
+		query.Set("include_dlc", "true")
+		query.Set("include_software", "true")
+		query.Set("include_videos", "true")
+		query.Set("include_hardware", "true")
+		if since > 0 {
+			query.Set("if_modified_since", strconv.FormatInt(since, 10))
+		}
+		if lastAppid > 0 {
+			query.Set("last_appid", strconv.Itoa(lastAppid))
+		}
+		body, err := steamClient.GetBody(ctx, "https://api.steampowered.com/IStoreService/GetAppList/v1/?"+query.Encode())
+		if err != nil {
+			return since, webAPIError(err)
+		}
+		var response struct {
+			Response struct {
+				Apps []struct {
+					Appid        int    `json:"appid"`
+					Name         string `json:"name"`
+					LastModified int64  `json:"last_modified"`
+				} `json:"apps"`
+				HaveMoreResults bool `json:"have_more_results"`
+				LastAppid       int  `json:"last_appid"`
+			} `json:"response"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return since, fmt.Errorf("failed to parse app list: %w: %w", ErrMarkupChanged, err)
+		}
+		for _, app := range response.Response.Apps {
+			if name := strings.TrimSpace(app.Name); name != "" {
+				names[app.Appid] = name
+			}
+			if app.LastModified > newest {
+				newest = app.LastModified
+			}
+		}
+		if !response.Response.HaveMoreResults || response.Response.LastAppid <= lastAppid {
+			return newest, nil
+		}
+		lastAppid = response.Response.LastAppid
+	}
+}
+
+// refreshAppCatalog brings a stale catalog up to date in the background
+func (a *App) refreshAppCatalog() {
+	if !a.cache.apps.Stale() {
+		return
+	}
+	go func() {
+		defer recoverGoroutine("app catalog refresh")
+		if err := a.cache.apps.Refresh(a.ctx, a.currentSettings().APIKey); err != nil {
+			fmt.Printf("Error refreshing app catalog: %v\n", err)
+		}
+	}()
+}
+
+// SearchApps finds apps by name in the offline catalog
+func (a *App) SearchApps(query string, limit int) []AppCatalogEntry {
+	return a.cache.apps.Search(query, limit)
+}
+
+func (a *App) RefreshAppCatalog() (err error) {
+	defer handleBindingError(&err)
+	return a.cache.apps.Refresh(a.ctx, a.currentSettings().APIKey)
+}
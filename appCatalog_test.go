@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFuzzyAppNameScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{"portal 2", "portal 2", 1000},
+		{"portal 2", "portal", 800},
+		{"half life 2", "life", 700},
+		{"counter strike 2", "str", 600},
+		{"counter strike 2", "trike", 500},
+		// c, then s after skipping "ounter " costs 7 gaps
+		{"counter strike 2", "cs", 330},
+		{"counter strike 2", "cs2", 270},
+		{"a" + strings.Repeat("x", 40) + "b", "ab", 0},
+		{"portal", "portals", 0},
+		{"portal", "xyz", 0},
+	}
+	for _, tt := range tests {
+		if got := fuzzyAppNameScore(tt.name, tt.query); got != tt.want {
+			t.Errorf("fuzzyAppNameScore(%q, %q) = %d, want %d", tt.name, tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestAppCatalogSearch(t *testing.T) {
+	catalog := &AppCatalog{}
+	catalog.replace(appCatalogFile{Apps: []AppCatalogEntry{
+		{Appid: 1, Name: "Teleportal"},
+		{Appid: 2, Name: "Aperture Portals"},
+		{Appid: 3, Name: "Bridge Constructor Portal"},
+		{Appid: 400, Name: "Portal"},
+		{Appid: 620, Name: "Portal 2"},
+		{Appid: 9, Name: "Portal 3"},
+		{Appid: 5, Name: "Portal Reloaded"},
+		{Appid: 6, Name: "Port Royale 4"},
+		{Appid: 7, Name: "Half-Life"},
+	}})
+
+	var got []int
+	for _, entry := range catalog.Search("PORTAL!", 10) {
+		got = append(got, entry.Appid)
+	}
+	// exact, prefixes by name length then appid, word, word prefix, substring, subsequence
+	want := []int{400, 9, 620, 5, 3, 2, 1, 6}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Search() = %v, want %v", got, want)
+	}
+	if results := catalog.Search("portal", 2); len(results) != 2 || results[1].Appid != 9 {
+		t.Errorf("Search() with limit 2 = %v", results)
+	}
+	if results := catalog.Search("  ", 10); len(results) != 0 {
+		t.Errorf("Search() of a blank query = %v, want no results", results)
+	}
+}
+
+// appListPage is one page of IStoreService/GetAppList
+type appListPage struct {
+	Apps            []map[string]any `json:"apps"`
+	HaveMoreResults bool             `json:"have_more_results,omitempty"`
+	LastAppid       int              `json:"last_appid,omitempty"`
+}
+
+func serveAppListPages(t *testing.T, pages map[string]appListPage, requests *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		*requests = append(*requests, query.Get("last_appid"))
+		if query.Get("key") == "rejected" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		page, ok := pages[query.Get("last_appid")]
+		if !ok {
+			t.Errorf("unexpected last_appid %q", query.Get("last_appid"))
+		}
+		json.NewEncoder(w).Encode(map[string]any{"response": page})
+	})
+}
+
+func TestFetchModifiedAppsPaging(t *testing.T) {
+	var requests []string
+	useTestSteamClient(t, serveAppListPages(t, map[string]appListPage{
+		"": {Apps: []map[string]any{
+			{"appid": 10, "name": "Counter-Strike", "last_modified": 1700000000},
+			{"appid": 20, "name": " ", "last_modified": 1700000100},
+		}, HaveMoreResults: true, LastAppid: 20},
+		"20": {Apps: []map[string]any{{"appid": 30, "name": "Day of Defeat", "last_modified": 1700000050}}, HaveMoreResults: true, LastAppid: 30},
+		"30": {Apps: []map[string]any{{"appid": 40, "name": "Deathmatch Classic", "last_modified": 1690000000}}},
+	}, &requests))
+
+	names := map[int]string{10: "Counter-Strike (old)", 50: "Opposing Force"}
+	newest, err := fetchModifiedApps(context.Background(), "key", 1600000000, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(requests, []string{"", "20", "30"}) {
+		t.Errorf("requested last_appid %q, want three pages", requests)
+	}
+	if newest != 1700000100 {
+		t.Errorf("newest = %d, want 1700000100", newest)
+	}
+	want := map[int]string{10: "Counter-Strike", 30: "Day of Defeat", 40: "Deathmatch Classic", 50: "Opposing Force"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestFetchModifiedAppsStopsWhenLastAppidRepeats(t *testing.T) {
+	var requests []string
+	useTestSteamClient(t, serveAppListPages(t, map[string]appListPage{
+		"":   {Apps: []map[string]any{{"appid": 10, "name": "Counter-Strike"}}, HaveMoreResults: true, LastAppid: 10},
+		"10": {HaveMoreResults: true, LastAppid: 10},
+	}, &requests))
+
+	if _, err := fetchModifiedApps(context.Background(), "key", 0, map[int]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 {
+		t.Errorf("made %d requests, want 2", len(requests))
+	}
+}
+
+func TestAppCatalogRefresh(t *testing.T) {
+	var requests []string
+	pages := serveAppListPages(t, map[string]appListPage{
+		"": {Apps: []map[string]any{{"appid": 730, "name": "Counter-Strike 2", "last_modified": 1700000000}}},
+	}, &requests)
+	useTestSteamClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/ISteamApps/GetAppList/") {
+			w.Write([]byte(`{"applist":{"apps":[{"appid":10,"name":"Counter-Strike"},{"appid":20,"name":""}]}}`))
+			return
+		}
+		pages.ServeHTTP(w, r)
+	}))
+
+	catalog := &AppCatalog{filename: filepath.Join(t.TempDir(), "apps.json")}
+	catalog.replace(appCatalogFile{Apps: []AppCatalogEntry{{Appid: 10, Name: "Counter-Strike"}}})
+	if err := catalog.Refresh(context.Background(), "key"); err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := catalog.Lookup(730); name != "Counter-Strike 2" || catalog.Len() != 2 || catalog.Stale() {
+		t.Errorf("after an incremental refresh Lookup(730) = %q, Len() = %d, Stale() = %v", name, catalog.Len(), catalog.Stale())
+	}
+	if catalog.lastModified != 1700000000 {
+		t.Errorf("lastModified = %d, want 1700000000", catalog.lastModified)
+	}
+
+	// A rejected key falls back to the full list, which replaces the catalog
+	if err := catalog.Refresh(context.Background(), "rejected"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := catalog.Lookup(730); ok || catalog.Len() != 1 {
+		t.Errorf("after the full list Len() = %d, want only the apps of the full list", catalog.Len())
+	}
+
+	reopened, err := loadAppCatalog(catalog.filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := reopened.Lookup(10); name != "Counter-Strike" || reopened.Len() != 1 {
+		t.Errorf("saved catalog has Lookup(10) = %q and %d apps", name, reopened.Len())
+	}
+}
+
+func TestAppCatalogRefreshErrorOmitsKey(t *testing.T) {
+	useTestSteamClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	catalog := &AppCatalog{}
+	err := catalog.Refresh(context.Background(), "SECRETKEY")
+	if err == nil {
+		t.Fatal("Refresh() succeeded against a failing server")
+	}
+	if binding := newBindingError(err); strings.Contains(binding.Message, "SECRETKEY") || binding.Code != ErrorCodeNetwork {
+		t.Errorf("Refresh() error = %+v, want a network error without the key", binding)
+	}
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// Where the badges of a ProfileBadges came from
+const (
+	BadgeSourceWebAPI     = "web_api"
+	BadgeSourceBadgesPage = "badges_page"
+)
+
+// badgesPageLimit caps how many pages of /badges are read, a page lists 150 badges
+const badgesPageLimit = 20
+
+// Badge is a badge a profile has crafted or earned. Game badges have an appid, the others
+// (years of service, community badges, ...) are told apart by BadgeID.
+type Badge struct {
+	BadgeID  int    `json:"badge_id"`
+	Appid    int    `json:"appid"`
+	Name     string `json:"name"`
+	GameName string `json:"game_name"`
+	Level    int    `json:"level"`
+	Foil     bool   `json:"foil"`
+	XP       int    `json:"xp"`
+	// CompletionTime is a unix timestamp like the timestamps of EquippedItem
+	CompletionTime int64  `json:"completion_time"`
+	Scarcity       int    `json:"scarcity"`
+	Image          string `json:"image"`
+}
+
+type ProfileBadges struct {
+	SteamID              SteamID `json:"steam_id" ts_type:"string"`
+	Level                int     `json:"level"`
+	XP                   int     `json:"xp"`
+	XPNeededToLevelUp    int     `json:"xp_needed_to_level_up"`
+	XPNeededCurrentLevel int     `json:"xp_needed_current_level"`
+	Badges               []Badge `json:"badges"`
+	Featured             *Badge  `json:"featured"`
+	Source               string  `json:"source"`
+}
+
+type playerBadgesResponse struct {
+	Response struct {
+		Badges []struct {
+			BadgeID        int   `json:"badgeid"`
+			Appid          int   `json:"appid"`
+			Level          int   `json:"level"`
+			CompletionTime int64 `json:"completion_time"`
+			XP             int   `json:"xp"`
+			Scarcity       int   `json:"scarcity"`
+			BorderColor    int   `json:"border_color"`
+		} `json:"badges"`
+		PlayerXP                   int `json:"player_xp"`
+		PlayerLevel                int `json:"player_level"`
+		PlayerXPNeededToLevelUp    int `json:"player_xp_needed_to_level_up"`
+		PlayerXPNeededCurrentLevel int `json:"player_xp_needed_current_level"`
+	} `json:"response"`
+}
+
+type favoriteBadgeResponse struct {
+	Response struct {
+		HasFavoriteBadge bool `json:"has_favorite_badge"`
+		BadgeID          int  `json:"badgeid"`
+		Appid            int  `json:"appid"`
+		Level            int  `json:"level"`
+		BorderColor      int  `json:"border_color"`
+	} `json:"response"`
+}
+
+// getPlayerServiceJSON calls a keyed IPlayerService method and decodes its response into v
+func getPlayerServiceJSON(ctx context.Context, method string, key string, steamID SteamID, v any) error {
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("steamid", steamID.Steam64())
+	body, err := steamClient.GetBody(ctx, "https://api.steampowered.com/IPlayerService/"+method+"/v1/?"+query.Encode())
+	if err != nil {
+		return webAPIError(err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w: %w", method, ErrMarkupChanged, err)
+	}
+	return nil
+}
+
+func getBadgesViaAPI(ctx context.Context, steamID SteamID, key string) (ProfileBadges, error) {
+	var response playerBadgesResponse
+	if err := getPlayerServiceJSON(ctx, "GetBadges", key, steamID, &response); err != nil {
+		return ProfileBadges{}, err
+	}
+	badges := ProfileBadges{
+		SteamID:              steamID,
+		Level:                response.Response.PlayerLevel,
+		XP:                   response.Response.PlayerXP,
+		XPNeededToLevelUp:    response.Response.PlayerXPNeededToLevelUp,
+		XPNeededCurrentLevel: response.Response.PlayerXPNeededCurrentLevel,
+		Badges:               make([]Badge, 0, len(response.Response.Badges)),
+		Source:               BadgeSourceWebAPI,
+	}
+	for _, badge := range response.Response.Badges {
+		badges.Badges = append(badges.Badges, Badge{
+			BadgeID:        badge.BadgeID,
+			Appid:          badge.Appid,
+			Level:          badge.Level,
+			Foil:           badge.BorderColor == 1,
+			XP:             badge.XP,
+			CompletionTime: badge.CompletionTime,
+			Scarcity:       badge.Scarcity,
+		})
+	}
+
+	// Private badge lists come back empty, the level is still public through GetSteamLevel
+	if badges.Level == 0 {
+		var level struct {
+			Response struct {
+				PlayerLevel int `json:"player_level"`
+			} `json:"response"`
+		}
+		if err := getPlayerServiceJSON(ctx, "GetSteamLevel", key, steamID, &level); err != nil {
+			return ProfileBadges{}, err
+		}
+		badges.Level = level.Response.PlayerLevel
+	}
+
+	var favorite favoriteBadgeResponse
+	if err := getPlayerServiceJSON(ctx, "GetFavoriteBadge", key, steamID, &favorite); err != nil {
+		return ProfileBadges{}, err
+	}
+	if favorite.Response.HasFavoriteBadge {
+		featured := Badge{
+			BadgeID: favorite.Response.BadgeID,
+			Appid:   favorite.Response.Appid,
+			Level:   favorite.Response.Level,
+			Foil:    favorite.Response.BorderColor == 1,
+		}
+		for _, badge := range badges.Badges {
+			if badge.BadgeID == featured.BadgeID && badge.Appid == featured.Appid && badge.Foil == featured.Foil {
+				featured = badge
+				break
+			}
+		}
+		badges.Featured = &featured
+	}
+	return badges, nil
+}
+
+var (
+	badgeGamecardsPattern = regexp.MustCompile(`/gamecards/(\d+)/?(\?border=1)?`)
+	badgeIDPattern        = regexp.MustCompile(`/badges/(\d+)`)
+	badgeLevelPattern     = regexp.MustCompile(`Level (\d+)`)
+	badgeXPPattern        = regexp.MustCompile(`([\d,]+) XP`)
+	badgeNumberPattern    = regexp.MustCompile(`([\d,]+)`)
+	badgeUnlockedPattern  = regexp.MustCompile(`Unlocked (.+)$`)
+)
+
+// badgeFromLink fills appid, foil and badge id from a link to the badge page
+func badgeFromLink(badge *Badge, href string) {
+	if matches := badgeGamecardsPattern.FindStringSubmatch(href); len(matches) > 1 {
+		badge.Appid, _ = strconv.Atoi(matches[1])
+		badge.Foil = matches[2] != ""
+	} else if matches := badgeIDPattern.FindStringSubmatch(href); len(matches) > 1 {
+		badge.BadgeID, _ = strconv.Atoi(matches[1])
+	}
+}
+
+func parseBadgeNumber(pattern *regexp.Regexp, text string) int {
+	matches := pattern.FindStringSubmatch(text)
+	if len(matches) < 2 {
+		return 0
+	}
+	number, _ := strconv.Atoi(strings.ReplaceAll(matches[1], ",", ""))
+	return number
+}
+
+// parseUnlockTime reads "Jun 5, 2020 @ 3:12pm", the year is left out for the current year
+func parseUnlockTime(text string, now time.Time) int64 {
+	text = strings.TrimSpace(text)
+	if unlocked, err := time.ParseInLocation("Jan 2, 2006 @ 3:04pm", text, time.UTC); err == nil {
+		return unlocked.Unix()
+	}
+	if unlocked, err := time.ParseInLocation("Jan 2 @ 3:04pm", text, time.UTC); err == nil {
+		return unlocked.AddDate(now.Year(), 0, 0).Unix()
+	}
+	return 0
+}
+
+// parseBadgesPage reads the badges and the level block of one /badges page and reports whether
+// the pager links to the page after it
+func parseBadgesPage(doc *html.Node, page int, now time.Time) (ProfileBadges, bool) {
+	var badges ProfileBadges
+	if level := findNode(doc, func(n *html.Node) bool { return hasClass(n, "profile_xp_block") }); level != nil {
+		if number := findNode(level, func(n *html.Node) bool { return hasClass(n, "friendPlayerLevelNum") }); number != nil {
+			badges.Level, _ = strconv.Atoi(nodeText(number))
+		}
+		if xp := findNode(level, func(n *html.Node) bool { return hasClass(n, "profile_xp_block_xp") }); xp != nil {
+			badges.XP = parseBadgeNumber(badgeNumberPattern, nodeText(xp))
+		}
+		if remaining := findNode(level, func(n *html.Node) bool { return hasClass(n, "profile_xp_block_remaining") }); remaining != nil {
+			badges.XPNeededToLevelUp = parseBadgeNumber(badgeXPPattern, nodeText(remaining))
+		}
+	}
+	rows := findNodes(doc, func(n *html.Node) bool { return hasClass(n, "badge_row") })
+	for _, row := range rows {
+		var badge Badge
+		if overlay := findNode(row, func(n *html.Node) bool { return hasClass(n, "badge_row_overlay") }); overlay != nil {
+			badgeFromLink(&badge, htmlAttr(overlay, "href"))
+		}
+		if title := findNode(row, func(n *html.Node) bool { return hasClass(n, "badge_info_title") }); title != nil {
+			badge.Name = nodeText(title)
+		}
+		if image := findNode(row, func(n *html.Node) bool { return hasClass(n, "badge_info_image") }); image != nil {
+			if img := findNode(image, func(n *html.Node) bool { return isElement(n, "img") }); img != nil {
+				badge.Image = imageSource(img)
+			}
+		}
+		if description := findNode(row, func(n *html.Node) bool { return hasClass(n, "badge_info_description") }); description != nil {
+			text := nodeText(description)
+			badge.Level = parseBadgeNumber(badgeLevelPattern, text)
+			badge.XP = parseBadgeNumber(badgeXPPattern, text)
+		}
+		if unlocked := findNode(row, func(n *html.Node) bool { return hasClass(n, "badge_info_unlocked") }); unlocked != nil {
+			if matches := badgeUnlockedPattern.FindStringSubmatch(nodeText(unlocked)); len(matches) > 1 {
+				badge.CompletionTime = parseUnlockTime(matches[1], now)
+			}
+		}
+		// Rows of badges that can still be crafted have no unlock time and no level
+		if badge.CompletionTime == 0 && badge.Level == 0 {
+			continue
+		}
+		badges.Badges = append(badges.Badges, badge)
+	}
+	next := fmt.Sprintf("p=%d", page+1)
+	hasNext := findNode(doc, func(n *html.Node) bool {
+		return hasClass(n, "pagebtn") && strings.Contains(htmlAttr(n, "href"), next)
+	}) != nil
+	return badges, hasNext
+}
+
+// parseFavoriteBadge reads the featured badge from the header of a profile page
+func parseFavoriteBadge(doc *html.Node) *Badge {
+	favorite := findNode(doc, func(n *html.Node) bool { return hasClass(n, "favorite_badge") })
+	if favorite == nil {
+		return nil
+	}
+	var badge Badge
+	if icon := findNode(favorite, func(n *html.Node) bool { return hasClass(n, "favorite_badge_icon") }); icon != nil {
+		badge.Level = parseBadgeNumber(badgeLevelPattern, htmlAttr(icon, "data-tooltip-html"))
+		if link := findNode(icon, func(n *html.Node) bool { return isElement(n, "a") }); link != nil {
+			badgeFromLink(&badge, htmlAttr(link, "href"))
+		}
+		if img := findNode(icon, func(n *html.Node) bool { return isElement(n, "img") }); img != nil {
+			badge.Image = imageSource(img)
+		}
+	}
+	if name := findNode(favorite, func(n *html.Node) bool { return hasClass(n, "name") }); name != nil {
+		badge.Name = nodeText(name)
+	}
+	if xp := findNode(favorite, func(n *html.Node) bool { return hasClass(n, "xp") }); xp != nil {
+		badge.XP = parseBadgeNumber(badgeXPPattern, nodeText(xp))
+	}
+	return &badge
+}
+
+func getHTMLDocument(ctx context.Context, pageURL string) (*html.Node, error) {
+	body, err := steamClient.GetBody(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w: %w", pageURL, ErrMarkupChanged, err)
+	}
+	return doc, nil
+}
+
+func getBadgesViaPage(ctx context.Context, steamID SteamID) (ProfileBadges, error) {
+	badges := ProfileBadges{SteamID: steamID, Badges: []Badge{}, Source: BadgeSourceBadgesPage}
+	now := time.Now()
+	for page := 1; page <= badgesPageLimit; page++ {
+		doc, err := getHTMLDocument(ctx, fmt.Sprintf("%s/badges/?l=english&p=%d", steamID.ProfileURL(), page))
+		if err != nil {
+			return ProfileBadges{}, err
+		}
+		if err := profilePageError(doc); err != nil {
+			return ProfileBadges{}, err
+		}
+		parsed, hasNext := parseBadgesPage(doc, page, now)
+		if page == 1 {
+			badges.Level, badges.XP, badges.XPNeededToLevelUp = parsed.Level, parsed.XP, parsed.XPNeededToLevelUp
+		}
+		badges.Badges = append(badges.Badges, parsed.Badges...)
+		if !hasNext {
+			break
+		}
+	}
+	return badges, nil
+}
+
+// badgeKey identifies a badge on both the API and the /badges page. The page links game badges
+// by appid and border only, so their badge id is left out.
+type badgeKey struct {
+	badgeID int
+	appid   int
+	foil    bool
+}
+
+func keyOfBadge(badge Badge) badgeKey {
+	if badge.Appid != 0 {
+		return badgeKey{appid: badge.Appid, foil: badge.Foil}
+	}
+	return badgeKey{badgeID: badge.BadgeID}
+}
+
+// fillBadgesFromPage copies the images and names the API doesn't return from the badges of the
+// /badges pages
+func fillBadgesFromPage(badges []Badge, page []Badge) {
+	byKey := make(map[badgeKey]Badge, len(page))
+	for _, badge := range page {
+		byKey[keyOfBadge(badge)] = badge
+	}
+	for i := range badges {
+		if pageBadge, ok := byKey[keyOfBadge(badges[i])]; ok {
+			badges[i].Image = pageBadge.Image
+			if badges[i].Name == "" {
+				badges[i].Name = pageBadge.Name
+			}
+		}
+	}
+}
+
+// GetBadges inspects the level and badges of steamID through IPlayerService when a key is
+// configured and through the /badges pages otherwise, or when the API fails for any reason.
+// The API returns no images, those are read from the /badges pages and, for the featured
+// badge, from the profile page.
+func GetBadges(ctx context.Context, steamID SteamID, key string, language string, cache *Cache) (ProfileBadges, error) {
+	var badges ProfileBadges
+	var err error
+	if key != "" {
+		badges, err = getBadgesViaAPI(ctx, steamID, key)
+		if err == nil && len(badges.Badges) > 0 {
+			if page, pageErr := getBadgesViaPage(ctx, steamID); pageErr == nil {
+				fillBadgesFromPage(badges.Badges, page.Badges)
+			}
+		}
+	}
+	if key == "" || (err != nil && ctx.Err() == nil) {
+		badges, err = getBadgesViaPage(ctx, steamID)
+	}
+	if err != nil {
+		return ProfileBadges{}, err
+	}
+
+	// The profile page and the game name only decorate the result, failing to load them is not fatal
+	if profile, err := getHTMLDocument(ctx, steamID.ProfileURL()); err == nil {
+		if featured := parseFavoriteBadge(profile); featured != nil {
+			if badges.Featured == nil {
+				badges.Featured = featured
+			} else {
+				badges.Featured.Image = featured.Image
+				if badges.Featured.Name == "" {
+					badges.Featured.Name = featured.Name
+				}
+			}
+		}
+	}
+	if badges.Featured != nil && badges.Featured.Appid != 0 {
+		badges.Featured.GameName, _ = getAppName(ctx, badges.Featured.Appid, language, cache)
+	}
+	if ctx.Err() != nil {
+		return ProfileBadges{}, ctx.Err()
+	}
+	return badges, nil
+}
+
+func (a *App) GetBadges(inspectionID string, steam64ID string) (_ ProfileBadges, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return ProfileBadges{}, err
+	}
+	steamID, err := ParseSteamID(steam64ID)
+	if err != nil {
+		return ProfileBadges{}, fmt.Errorf("%w: %w", ErrInvalidSteamID, err)
+	}
+	return GetBadges(ctx, steamID, a.currentSettings().APIKey, a.languageFor(""), a.cache)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBadgesPage(t *testing.T) {
+	_, doc := parseTestdata(t, "testdata/badges/badges_page.html")
+	badges, hasNext := parseBadgesPage(doc, 1, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if !hasNext {
+		t.Error("expected a link to the second page")
+	}
+	checkGolden(t, "badges", "badges_page", badges)
+}
+
+func TestParseFavoriteBadge(t *testing.T) {
+	_, doc := parseTestdata(t, "testdata/badges/profile_favorite_badge.html")
+	checkGolden(t, "badges", "profile_favorite_badge", parseFavoriteBadge(doc))
+}
+
+func TestFillBadgesFromPage(t *testing.T) {
+	_, doc := parseTestdata(t, "testdata/badges/badges_page.html")
+	page, _ := parseBadgesPage(doc, 1, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	badges := []Badge{
+		{BadgeID: 1, Appid: 730, Level: 5},
+		{BadgeID: 1, Appid: 1263950, Level: 1, Foil: true},
+		{BadgeID: 1, Level: 9},
+		{BadgeID: 1, Appid: 1263950, Level: 1},
+	}
+	fillBadgesFromPage(badges, page.Badges)
+	for i, want := range []Badge{page.Badges[0], page.Badges[1], page.Badges[2], {}} {
+		if badges[i].Image != want.Image || badges[i].Name != want.Name {
+			t.Errorf("badge %d = %q, %q, want %q, %q", i, badges[i].Name, badges[i].Image, want.Name, want.Image)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type CacheStatus int
+
+const (
+	CacheMiss CacheStatus = iota
+	CacheHit
+	// CacheNegativeHit means we already asked upstream and it had nothing for the key
+	CacheNegativeHit
+)
+
+type CacheStats struct {
+	Entries   int    `json:"entries"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+}
+
+type ttlCacheEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	negative  bool
+	expiresAt time.Time
+}
+
+// TTLCache is a mutex-protected LRU cache whose entries expire individually.
+// Expired entries are dropped lazily on lookup and by PurgeExpired.
+type TTLCache[K comparable, V any] struct {
+	mu         sync.Mutex
+	entries    map[K]*list.Element
+	order      *list.List
+	maxEntries int
+	hits       uint64
+	misses     uint64
+	evictions  uint64
+}
+
+// NewTTLCache creates a cache holding at most maxEntries items, 0 means unbounded
+func NewTTLCache[K comparable, V any](maxEntries int) *TTLCache[K, V] {
+	return &TTLCache[K, V]{
+		entries:    make(map[K]*list.Element),
+		order:      list.New(),
+		maxEntries: maxEntries,
+	}
+}
+
+func (c *TTLCache[K, V]) Get(key K) (V, CacheStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zero V
+	element, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return zero, CacheMiss
+	}
+	entry := element.Value.(*ttlCacheEntry[K, V])
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(element)
+		c.evictions++
+		c.misses++
+		return zero, CacheMiss
+	}
+	c.order.MoveToFront(element)
+	c.hits++
+	if entry.negative {
+		return zero, CacheNegativeHit
+	}
+	return entry.value, CacheHit
+}
+
+func (c *TTLCache[K, V]) Set(key K, value V, ttl time.Duration) {
+	c.set(&ttlCacheEntry[K, V]{key: key, value: value, expiresAt: time.Now().Add(ttl)})
+}
+
+// SetNegative remembers that key has no value for ttl
+func (c *TTLCache[K, V]) SetNegative(key K, ttl time.Duration) {
+	c.set(&ttlCacheEntry[K, V]{key: key, negative: true, expiresAt: time.Now().Add(ttl)})
+}
+
+func (c *TTLCache[K, V]) set(entry *ttlCacheEntry[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[entry.key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.removeElement(c.order.Back())
+		c.evictions++
+	}
+}
+
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+}
+
+// PurgeExpired drops every expired entry and returns how many were removed
+func (c *TTLCache[K, V]) PurgeExpired() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	removed := 0
+	for element := c.order.Back(); element != nil; {
+		prev := element.Prev()
+		if now.After(element.Value.(*ttlCacheEntry[K, V]).expiresAt) {
+			c.removeElement(element)
+			removed++
+		}
+		element = prev
+	}
+	c.evictions += uint64(removed)
+	return removed
+}
+
+func (c *TTLCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[K]*list.Element)
+	c.order.Init()
+}
+
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *TTLCache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Entries: c.order.Len(), Hits: c.hits, Misses: c.misses, Evictions: c.evictions}
+}
+
+func (c *TTLCache[K, V]) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*ttlCacheEntry[K, V]).key)
+}
+
+const cacheJanitorInterval = time.Minute
+
+// Cache holds everything the app looked up during the session
+type Cache struct {
+	backgrounds *TTLCache[string, ProfileBackground]
+	games       *TTLCache[string, AppDetails]
+	// priceHistories is keyed by market hash name
+	priceHistories *TTLCache[string, PriceHistory]
+	// orderBooks is keyed by "nameid:currency:language", see orderBookCacheKey
+	orderBooks *TTLCache[string, OrderBook]
+	disk       *DiskCache
+	apps       *AppCatalog
+	stop       chan struct{}
+	stopOnce   sync.Once
+}
+
+func NewCache() *Cache {
+	disk, err := OpenDiskCache()
+	if err != nil {
+		fmt.Printf("Error loading cache from disk: %v", err)
+	}
+	apps, err := OpenAppCatalog()
+	if err != nil {
+		fmt.Printf("Error loading app catalog: %v", err)
+	}
+	c := &Cache{
+		backgrounds:    NewTTLCache[string, ProfileBackground](512),
+		games:          NewTTLCache[string, AppDetails](2048),
+		priceHistories: NewTTLCache[string, PriceHistory](256),
+		orderBooks:     NewTTLCache[string, OrderBook](256),
+		disk:           disk,
+		apps:           apps,
+		stop:           make(chan struct{}),
+	}
+	go c.janitor()
+	return c
+}
+
+// janitor is the only background goroutine of the cache, it sweeps expired entries of every store
+// and persists the disk cache
+func (c *Cache) janitor() {
+	ticker := time.NewTicker(cacheJanitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.backgrounds.PurgeExpired()
+			c.games.PurgeExpired()
+			c.priceHistories.PurgeExpired()
+			c.orderBooks.PurgeExpired()
+			if err := c.disk.Flush(); err != nil {
+				fmt.Printf("Error saving cache to disk: %v", err)
+			}
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Close stops the janitor and saves the disk cache
+func (c *Cache) Close() error {
+	c.stopOnce.Do(func() { close(c.stop) })
+	return c.disk.Flush()
+}
+
+// Clear drops every cached value, including the file on disk. The app catalog is kept,
+// it is a dataset that is refreshed rather than a cache of lookups.
+func (c *Cache) Clear() error {
+	c.backgrounds.Clear()
+	c.games.Clear()
+	c.priceHistories.Clear()
+	c.orderBooks.Clear()
+	return c.disk.Clear()
+}
+
+// CacheStatsByStore has the stats of every store of a Cache. The disk cache and the app
+// catalog only count their entries.
+type CacheStatsByStore struct {
+	Backgrounds    CacheStats `json:"backgrounds"`
+	Games          CacheStats `json:"games"`
+	PriceHistories CacheStats `json:"price_histories"`
+	OrderBooks     CacheStats `json:"order_books"`
+	Disk           CacheStats `json:"disk"`
+	Apps           CacheStats `json:"apps"`
+}
+
+func (c *Cache) Stats() CacheStatsByStore {
+	return CacheStatsByStore{
+		Backgrounds:    c.backgrounds.Stats(),
+		Games:          c.games.Stats(),
+		PriceHistories: c.priceHistories.Stats(),
+		OrderBooks:     c.orderBooks.Stats(),
+		Disk:           CacheStats{Entries: c.disk.Len()},
+		Apps:           CacheStats{Entries: c.apps.Len()},
+	}
+}
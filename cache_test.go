@@ -0,0 +1,105 @@
+package main
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTTLCacheExpiry(t *testing.T) {
+	c := NewTTLCache[string, int](0)
+	c.Set("short", 1, time.Millisecond)
+	c.Set("long", 2, time.Hour)
+	time.Sleep(5 * time.Millisecond)
+	if _, status := c.Get("short"); status != CacheMiss {
+		t.Errorf("expired entry = %v, want a miss", status)
+	}
+	if value, status := c.Get("long"); status != CacheHit || value != 2 {
+		t.Errorf("live entry = %d, %v, want 2, hit", value, status)
+	}
+	c.Set("short", 1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if removed := c.PurgeExpired(); removed != 1 || c.Len() != 1 {
+		t.Errorf("PurgeExpired removed %d and left %d, want 1 and 1", removed, c.Len())
+	}
+}
+
+func TestTTLCacheLRUEviction(t *testing.T) {
+	c := NewTTLCache[string, int](2)
+	c.Set("a", 1, time.Hour)
+	c.Set("b", 2, time.Hour)
+	c.Get("a")
+	c.Set("c", 3, time.Hour)
+	if _, status := c.Get("b"); status != CacheMiss {
+		t.Error("least recently used entry b was kept")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, status := c.Get(key); status != CacheHit {
+			t.Errorf("entry %s was evicted", key)
+		}
+	}
+	c.Set("a", 10, time.Hour)
+	c.Set("d", 4, time.Hour)
+	if _, status := c.Get("c"); status != CacheMiss {
+		t.Error("overwriting a did not make it the most recently used entry")
+	}
+	if value, _ := c.Get("a"); value != 10 {
+		t.Errorf("a = %d, want the overwritten 10", value)
+	}
+}
+
+func TestTTLCacheNegativeEntries(t *testing.T) {
+	c := NewTTLCache[string, string](0)
+	c.SetNegative("gone", time.Hour)
+	if value, status := c.Get("gone"); status != CacheNegativeHit || value != "" {
+		t.Errorf("negative entry = %q, %v, want a negative hit", value, status)
+	}
+	c.Set("gone", "back", time.Hour)
+	if value, status := c.Get("gone"); status != CacheHit || value != "back" {
+		t.Errorf("entry set over a negative one = %q, %v", value, status)
+	}
+	c.SetNegative("short", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, status := c.Get("short"); status != CacheMiss {
+		t.Errorf("expired negative entry = %v, want a miss", status)
+	}
+}
+
+func TestTTLCacheStats(t *testing.T) {
+	c := NewTTLCache[string, int](1)
+	c.Set("a", 1, time.Hour)
+	c.Get("a")
+	c.Get("b")
+	c.Set("b", 2, time.Hour)
+	want := CacheStats{Entries: 1, Hits: 1, Misses: 1, Evictions: 1}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestTTLCacheConcurrentAccess(t *testing.T) {
+	c := NewTTLCache[string, int](64)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa((w*31 + i) % 100)
+				if i%3 == 0 {
+					c.Set(key, i, time.Minute)
+				} else if i%7 == 0 {
+					c.SetNegative(key, time.Minute)
+				} else {
+					c.Get(key)
+				}
+			}
+			c.PurgeExpired()
+		}(w)
+	}
+	wg.Wait()
+	if c.Len() > 64 {
+		t.Errorf("cache grew to %d entries, the limit is 64", c.Len())
+	}
+}
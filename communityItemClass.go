@@ -0,0 +1,64 @@
+package main
+
+// CommunityItemClass mirrors Steam's ECommunityItemClass
+type CommunityItemClass int
+
+const (
+	CommunityItemClassInvalid CommunityItemClass = iota
+	CommunityItemClassBadge
+	CommunityItemClassTradingCard
+	CommunityItemClassProfileBackground
+	CommunityItemClassEmoticon
+	CommunityItemClassBoosterPack
+	CommunityItemClassConsumable
+	CommunityItemClassGems
+	CommunityItemClassProfileModifier
+	CommunityItemClassScene
+	CommunityItemClassSalienItem
+	CommunityItemClassSticker
+	CommunityItemClassChatEffect
+	CommunityItemClassMiniProfileBackground
+	CommunityItemClassAvatarFrame
+	CommunityItemClassAnimatedAvatar
+	CommunityItemClassKeyboardSkin
+	CommunityItemClassStartupMovie
+)
+
+var communityItemClassNames = map[CommunityItemClass]string{
+	CommunityItemClassInvalid:               "invalid",
+	CommunityItemClassBadge:                 "badge",
+	CommunityItemClassTradingCard:           "trading_card",
+	CommunityItemClassProfileBackground:     "profile_background",
+	CommunityItemClassEmoticon:              "emoticon",
+	CommunityItemClassBoosterPack:           "booster_pack",
+	CommunityItemClassConsumable:            "consumable",
+	CommunityItemClassGems:                  "gems",
+	CommunityItemClassProfileModifier:       "profile_modifier",
+	CommunityItemClassScene:                 "scene",
+	CommunityItemClassSalienItem:            "salien_item",
+	CommunityItemClassSticker:               "sticker",
+	CommunityItemClassChatEffect:            "chat_effect",
+	CommunityItemClassMiniProfileBackground: "mini_profile_background",
+	CommunityItemClassAvatarFrame:           "avatar_frame",
+	CommunityItemClassAnimatedAvatar:        "animated_avatar",
+	CommunityItemClassKeyboardSkin:          "keyboard_skin",
+	CommunityItemClassStartupMovie:          "startup_movie",
+}
+
+func (c CommunityItemClass) String() string {
+	if name, ok := communityItemClassNames[c]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// IsEquippable reports whether items of the class occupy a profile slot
+func (c CommunityItemClass) IsEquippable() bool {
+	switch c {
+	case CommunityItemClassProfileBackground, CommunityItemClassMiniProfileBackground, CommunityItemClassAvatarFrame,
+		CommunityItemClassAnimatedAvatar, CommunityItemClassProfileModifier, CommunityItemClassKeyboardSkin,
+		CommunityItemClassStartupMovie:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const defaultCurrency = 1
+
+// SymbolPlacement is the side of the number Steam puts the currency symbol on
+type SymbolPlacement string
+
+const (
+	SymbolPrefix SymbolPlacement = "prefix"
+	SymbolSuffix SymbolPlacement = "suffix"
+)
+
+// SteamCurrency is a wallet currency and the way Steam formats it. Steam keeps amounts of
+// every currency in hundredths, Decimals is only how many of them it displays: currencies with
+// none still show the hundredths of an amount that has some.
+type SteamCurrency struct {
+	ID                 int             `json:"id"`
+	Code               string          `json:"code"`
+	Symbol             string          `json:"symbol"`
+	Name               string          `json:"name"`
+	Decimals           int             `json:"decimals"`
+	Placement          SymbolPlacement `json:"placement"`
+	SymbolSeparator    string          `json:"symbol_separator"`
+	DecimalSeparator   string          `json:"decimal_separator"`
+	ThousandsSeparator string          `json:"thousands_separator"`
+}
+
+// steamCurrencies are the wallet currencies the market accepts in currency= parameters, by ECurrencyCode
+var steamCurrencies = []SteamCurrency{
+	{ID: 1, Code: "USD", Symbol: "$", Name: "United States Dollar", Decimals: 2, Placement: SymbolPrefix, DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 2, Code: "GBP", Symbol: "£", Name: "United Kingdom Pound", Decimals: 2, Placement: SymbolPrefix, DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 3, Code: "EUR", Symbol: "€", Name: "European Union Euro", Decimals: 2, Placement: SymbolSuffix, DecimalSeparator: ",", ThousandsSeparator: "."},
+	{ID: 4, Code: "CHF", Symbol: "CHF", Name: "Swiss Francs", Decimals: 2, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: " "},
+	{ID: 5, Code: "RUB", Symbol: "₽", Name: "Russian Rouble", Decimals: 2, Placement: SymbolSuffix, DecimalSeparator: ",", ThousandsSeparator: " "},
+	{ID: 6, Code: "PLN", Symbol: "zł", Name: "Polish Złoty", Decimals: 2, Placement: SymbolSuffix, DecimalSeparator: ",", ThousandsSeparator: " "},
+	{ID: 7, Code: "BRL", Symbol: "R$", Name: "Brazilian Reals", Decimals: 2, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: "."},
+	{ID: 8, Code: "JPY", Symbol: "¥", Name: "Japanese Yen", Decimals: 0, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 9, Code: "NOK", Symbol: "kr", Name: "Norwegian Krone", Decimals: 2, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: " "},
+	{ID: 10, Code: "IDR", Symbol: "Rp", Name: "Indonesian Rupiah", Decimals: 0, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: " "},
+	{ID: 11, Code: "MYR", Symbol: "RM", Name: "Malaysian Ringgit", Decimals: 2, Placement: SymbolPrefix, DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 12, Code: "PHP", Symbol: "₱", Name: "Philippine Peso", Decimals: 2, Placement: SymbolPrefix, DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 13, Code: "SGD", Symbol: "S$", Name: "Singapore Dollar", Decimals: 2, Placement: SymbolPrefix, DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 14, Code: "THB", Symbol: "฿", Name: "Thai Baht", Decimals: 2, Placement: SymbolPrefix, DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 15, Code: "VND", Symbol: "₫", Name: "Vietnamese Dong", Decimals: 0, Placement: SymbolSuffix, DecimalSeparator: ",", ThousandsSeparator: "."},
+	{ID: 16, Code: "KRW", Symbol: "₩", Name: "South Korean Won", Decimals: 0, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 17, Code: "TRY", Symbol: "₺", Name: "Turkish Lira", Decimals: 2, Placement: SymbolPrefix, DecimalSeparator: ",", ThousandsSeparator: "."},
+	{ID: 18, Code: "UAH", Symbol: "₴", Name: "Ukrainian Hryvnia", Decimals: 0, Placement: SymbolSuffix, DecimalSeparator: ",", ThousandsSeparator: " "},
+	{ID: 19, Code: "MXN", Symbol: "Mex$", Name: "Mexican Peso", Decimals: 2, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 20, Code: "CAD", Symbol: "CDN$", Name: "Canadian Dollars", Decimals: 2, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 21, Code: "AUD", Symbol: "A$", Name: "Australian Dollars", Decimals: 2, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 22, Code: "NZD", Symbol: "NZ$", Name: "New Zealand Dollar", Decimals: 2, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 23, Code: "CNY", Symbol: "¥", Name: "Chinese Renminbi (yuan)", Decimals: 2, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 24, Code: "INR", Symbol: "₹", Name: "Indian Rupee", Decimals: 0, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 25, Code: "CLP", Symbol: "CLP$", Name: "Chilean Peso", Decimals: 0, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: "."},
+	{ID: 26, Code: "PEN", Symbol: "S/.", Name: "Peruvian Sol", Decimals: 2, Placement: SymbolPrefix, DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 27, Code: "COP", Symbol: "COL$", Name: "Colombian Peso", Decimals: 0, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: "."},
+	{ID: 28, Code: "ZAR", Symbol: "R", Name: "South African Rand", Decimals: 2, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: " "},
+	{ID: 29, Code: "HKD", Symbol: "HK$", Name: "Hong Kong Dollar", Decimals: 2, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 30, Code: "TWD", Symbol: "NT$", Name: "New Taiwan Dollar", Decimals: 0, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 31, Code: "SAR", Symbol: "SR", Name: "Saudi Riyal", Decimals: 2, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 32, Code: "AED", Symbol: "AED", Name: "United Arab Emirates Dirham", Decimals: 2, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 33, Code: "SEK", Symbol: "kr", Name: "Swedish Krona", Decimals: 2, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: " "},
+	{ID: 34, Code: "ARS", Symbol: "ARS$", Name: "Argentine Peso", Decimals: 2, Placement: SymbolPrefix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: "."},
+	{ID: 35, Code: "ILS", Symbol: "₪", Name: "Israeli New Shekel", Decimals: 2, Placement: SymbolPrefix, DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 36, Code: "BYN", Symbol: "Br", Name: "Belarusian Ruble", Decimals: 2, Placement: SymbolPrefix, DecimalSeparator: ".", ThousandsSeparator: " "},
+	{ID: 37, Code: "KZT", Symbol: "₸", Name: "Kazakhstani Tenge", Decimals: 0, Placement: SymbolSuffix, DecimalSeparator: ",", ThousandsSeparator: " "},
+	{ID: 38, Code: "KWD", Symbol: "KD", Name: "Kuwaiti Dinar", Decimals: 2, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 39, Code: "QAR", Symbol: "QR", Name: "Qatari Riyal", Decimals: 2, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ".", ThousandsSeparator: ","},
+	{ID: 40, Code: "CRC", Symbol: "₡", Name: "Costa Rican Colón", Decimals: 0, Placement: SymbolPrefix, DecimalSeparator: ",", ThousandsSeparator: "."},
+	{ID: 41, Code: "UYU", Symbol: "$U", Name: "Uruguayan Peso", Decimals: 0, Placement: SymbolPrefix, DecimalSeparator: ",", ThousandsSeparator: "."},
+	{ID: 42, Code: "BGN", Symbol: "лв", Name: "Bulgarian Lev", Decimals: 2, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: " "},
+	{ID: 43, Code: "HRK", Symbol: "kn", Name: "Croatian Kuna", Decimals: 2, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: "."},
+	{ID: 44, Code: "CZK", Symbol: "Kč", Name: "Czech Koruna", Decimals: 2, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: " "},
+	{ID: 45, Code: "DKK", Symbol: "kr.", Name: "Danish Krone", Decimals: 2, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: "."},
+	{ID: 46, Code: "HUF", Symbol: "Ft", Name: "Hungarian Forint", Decimals: 0, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: " "},
+	{ID: 47, Code: "RON", Symbol: "lei", Name: "Romanian Leu", Decimals: 2, Placement: SymbolSuffix, SymbolSeparator: " ", DecimalSeparator: ",", ThousandsSeparator: "."},
+}
+
+func currencyByID(id int) (SteamCurrency, bool) {
+	for _, currency := range steamCurrencies {
+		if currency.ID == id {
+			return currency, true
+		}
+	}
+	return SteamCurrency{}, false
+}
+
+// validateCurrency returns ErrInvalidCurrency unless id is in steamCurrencies
+func validateCurrency(id int) error {
+	if _, ok := currencyByID(id); !ok {
+		return fmt.Errorf("%w: %d", ErrInvalidCurrency, id)
+	}
+	return nil
+}
+
+// Format displays an amount in hundredths the way Steam does, like "$1,234.56" or "1 234,56₽"
+func (c SteamCurrency) Format(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	whole := strconv.FormatInt(amount/100, 10)
+	var number strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			number.WriteString(c.ThousandsSeparator)
+		}
+		number.WriteRune(digit)
+	}
+	if cents := amount % 100; c.Decimals > 0 || cents != 0 {
+		fmt.Fprintf(&number, "%s%02d", c.DecimalSeparator, cents)
+	}
+	if c.Placement == SymbolSuffix {
+		return sign + number.String() + c.SymbolSeparator + c.Symbol
+	}
+	return sign + c.Symbol + c.SymbolSeparator + number.String()
+}
+
+// Parse reads a price formatted by Steam in c, like "1 234,56₽", "$0.12 USD" or "0,--€", into
+// hundredths. It knows which separator is the decimal one from c.
+func (c SteamCurrency) Parse(text string) (int64, error) {
+	number := strings.TrimSpace(text)
+	number = strings.TrimSpace(strings.TrimSuffix(number, c.Code))
+	number = strings.Replace(number, c.Symbol, "", 1)
+	number = strings.ReplaceAll(number, "--", "00")
+	negative := strings.HasPrefix(strings.TrimSpace(number), "-")
+	if negative {
+		number = strings.Replace(number, "-", "", 1)
+	}
+	whole, fraction := number, ""
+	if i := strings.LastIndex(number, c.DecimalSeparator); i >= 0 {
+		whole, fraction = number[:i], number[i+len(c.DecimalSeparator):]
+	}
+	whole = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, strings.ReplaceAll(whole, c.ThousandsSeparator, ""))
+	fraction = strings.TrimSpace(fraction)
+	if !isDigits(whole) || len(fraction) > 2 || (fraction != "" && !isDigits(fraction)) {
+		return 0, fmt.Errorf("%w: %q is not a price in %s", ErrMarkupChanged, text, c.Code)
+	}
+	amount, err := strconv.ParseInt(whole+(fraction + "00")[:2], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not a price in %s: %w", ErrMarkupChanged, text, c.Code, err)
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// ParseWithin reads the price of c that text labels in the page language, like the "$0.14 or
+// more" of an order table. It cuts from the symbol or first digit to the last digit or symbol, "--" cents included.
+func (c SteamCurrency) ParseWithin(text string) (int64, error) {
+	start := strings.IndexFunc(text, func(r rune) bool { return r >= '0' && r <= '9' })
+	if start < 0 {
+		return 0, fmt.Errorf("%w: %q has no price in %s", ErrMarkupChanged, text, c.Code)
+	}
+	end := strings.LastIndexFunc(text, func(r rune) bool { return r >= '0' && r <= '9' }) + 1
+	if strings.HasPrefix(text[end:], c.DecimalSeparator+"--") {
+		end += len(c.DecimalSeparator) + 2
+	}
+	if c.Placement == SymbolPrefix {
+		if i := strings.LastIndex(text[:start], c.Symbol); i >= 0 {
+			start = i
+		}
+	} else if i := strings.Index(text[end:], c.Symbol); i >= 0 {
+		end += i + len(c.Symbol)
+	}
+	return c.Parse(text[start:end])
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// GetCurrencies returns the currencies prices can be looked up in
+func (a *App) GetCurrencies() []SteamCurrency {
+	return steamCurrencies
+}
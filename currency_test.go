@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCurrencyFormat(t *testing.T) {
+	tests := []struct {
+		currency int
+		amount   int64
+		want     string
+	}{
+		{1, 12, "$0.12"},
+		{1, 123456, "$1,234.56"},
+		{3, 12, "0,12€"},
+		{3, 12345678, "123.456,78€"},
+		{5, 123456, "1 234,56₽"},
+		{8, 123400, "¥ 1,234"},
+		{8, 123456, "¥ 1,234.56"},
+		{20, 100, "CDN$ 1.00"},
+		{33, 999, "9,99 kr"},
+	}
+	for _, test := range tests {
+		currency, _ := currencyByID(test.currency)
+		if got := currency.Format(test.amount); got != test.want {
+			t.Errorf("%s.Format(%d) = %q, want %q", currency.Code, test.amount, got, test.want)
+		}
+	}
+}
+
+func TestCurrencyParse(t *testing.T) {
+	tests := []struct {
+		currency int
+		text     string
+		want     int64
+	}{
+		{1, "$0.12 USD", 12},
+		{1, "$1,234.56", 123456},
+		{3, "0,--€", 0},
+		{3, "1.234,5€", 123450},
+		{5, "1 234,56₽", 123456},
+		{5, "1 234,56 ₽", 123456},
+		{8, "¥ 1,234", 123400},
+		{32, "1,234.56 AED", 123456},
+	}
+	for _, test := range tests {
+		currency, _ := currencyByID(test.currency)
+		got, err := currency.Parse(test.text)
+		if err != nil || got != test.want {
+			t.Errorf("%s.Parse(%q) = %d, %v, want %d", currency.Code, test.text, got, err, test.want)
+		}
+	}
+	usd, _ := currencyByID(1)
+	for _, text := range []string{"", "$", "$1.234", "free"} {
+		if _, err := usd.Parse(text); !errors.Is(err, ErrMarkupChanged) {
+			t.Errorf("USD.Parse(%q) = %v, want ErrMarkupChanged", text, err)
+		}
+	}
+}
+
+func TestCurrencyParseWithin(t *testing.T) {
+	tests := []struct {
+		currency int
+		text     string
+		want     int64
+	}{
+		{1, "$0.14 or more", 14},
+		{1, "$1,234.56 or less", 123456},
+		{3, "0,14€ oder mehr", 14},
+		{3, "0,--€ ou moins", 0},
+		{5, "1 234,56₽ или больше", 123456},
+		{8, "¥ 1,234 以上", 123400},
+		{9, "12,50 kr eller mer", 1250},
+		{4, "CHF 3.50 or more", 350},
+		{3, "ab 1,5€", 150},
+	}
+	for _, test := range tests {
+		currency, _ := currencyByID(test.currency)
+		got, err := currency.ParseWithin(test.text)
+		if err != nil || got != test.want {
+			t.Errorf("%s.ParseWithin(%q) = %d, %v, want %d", currency.Code, test.text, got, err, test.want)
+		}
+	}
+	usd, _ := currencyByID(1)
+	for _, text := range []string{"", "or more", "$"} {
+		if _, err := usd.ParseWithin(text); !errors.Is(err, ErrMarkupChanged) {
+			t.Errorf("USD.ParseWithin(%q) = %v, want ErrMarkupChanged", text, err)
+		}
+	}
+}
+
+func TestCurrencyRoundTrip(t *testing.T) {
+	seen := map[int]bool{}
+	for _, currency := range steamCurrencies {
+		if seen[currency.ID] {
+			t.Errorf("currency %d is listed twice", currency.ID)
+		}
+		seen[currency.ID] = true
+		for _, amount := range []int64{0, 1, 12, 100, 99999, 123456789} {
+			text := currency.Format(amount)
+			if got, err := currency.Parse(text); err != nil || got != amount {
+				t.Errorf("%s.Parse(%q) = %d, %v, want %d", currency.Code, text, got, err, amount)
+			}
+		}
+	}
+}
+
+func TestNormalizeSettingsCurrency(t *testing.T) {
+	if got := normalizeSettings(AppSettings{SteamCurrency: 5}).SteamCurrency; got != 5 {
+		t.Errorf("supported currency normalized to %d", got)
+	}
+	for _, id := range []int{0, -1, 48} {
+		if got := normalizeSettings(AppSettings{SteamCurrency: id}).SteamCurrency; got != defaultCurrency {
+			t.Errorf("currency %d normalized to %d, want %d", id, got, defaultCurrency)
+		}
+		if err := validateCurrency(id); errorCodeOf(err) != ErrorCodeInvalidInput {
+			t.Errorf("validateCurrency(%d) = %v, want invalid_input", id, err)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+type DiskCacheKind string
+
+const (
+	DiskCacheAppName      DiskCacheKind = "app_name"
+	DiskCacheMarketNameID DiskCacheKind = "market_nameid"
+	DiskCacheVanity       DiskCacheKind = "vanity"
+	DiskCacheMarketPrice  DiskCacheKind = "market_price"
+)
+
+// App names and nameids practically never change, prices go stale in minutes
+var diskCacheTTLs = map[DiskCacheKind]time.Duration{
+	DiskCacheAppName:      30 * 24 * time.Hour,
+	DiskCacheMarketNameID: 180 * 24 * time.Hour,
+	DiskCacheVanity:       7 * 24 * time.Hour,
+	DiskCacheMarketPrice:  10 * time.Minute,
+}
+
+var diskCacheLimits = map[DiskCacheKind]int{
+	DiskCacheAppName:      20000,
+	DiskCacheMarketNameID: 20000,
+	DiskCacheVanity:       5000,
+	DiskCacheMarketPrice:  2000,
+}
+
+type diskCacheEntry struct {
+	Value     string    `json:"value,omitempty"`
+	Negative  bool      `json:"negative,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DiskCache is a small key-value store persisted as cache.json next to settings.json
+type DiskCache struct {
+	mu       sync.Mutex
+	filename string
+	entries  map[DiskCacheKind]map[string]diskCacheEntry
+	dirty    bool
+}
+
+// OpenDiskCache loads the cache file from the app data directory. On error the returned cache
+// still works, it just starts empty.
+func OpenDiskCache() (*DiskCache, error) {
+	dir, err := getAppdataDir()
+	if err != nil {
+		return &DiskCache{entries: make(map[DiskCacheKind]map[string]diskCacheEntry)}, err
+	}
+	return loadDiskCache(filepath.Join(dir, "cache.json"))
+}
+
+// loadDiskCache reads filename, dropping the entries that expired while the app was closed. A
+// missing file is an empty cache, a corrupt one is reported and replaced on the next flush.
+func loadDiskCache(filename string) (*DiskCache, error) {
+	c := &DiskCache{filename: filename, entries: make(map[DiskCacheKind]map[string]diskCacheEntry)}
+	data, err := os.ReadFile(c.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c.entries)
+	if err != nil {
+		c.entries = make(map[DiskCacheKind]map[string]diskCacheEntry)
+		c.dirty = true
+		return c, err
+	}
+	now := time.Now()
+	for kind, entries := range c.entries {
+		if entries == nil {
+			delete(c.entries, kind)
+			continue
+		}
+		for key, entry := range entries {
+			if now.After(entry.ExpiresAt) {
+				delete(entries, key)
+				c.dirty = true
+			}
+		}
+	}
+	return c, nil
+}
+
+func (c *DiskCache) Get(kind DiskCacheKind, key string) (string, CacheStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[kind][key]
+	if !ok {
+		return "", CacheMiss
+	}
+	if time.Now().After(entry.ExpiresAt) {
+		delete(c.entries[kind], key)
+		c.dirty = true
+		return "", CacheMiss
+	}
+	if entry.Negative {
+		return "", CacheNegativeHit
+	}
+	return entry.Value, CacheHit
+}
+
+// Set stores value with the TTL of its kind
+func (c *DiskCache) Set(kind DiskCacheKind, key string, value string) {
+	c.set(kind, key, diskCacheEntry{Value: value, ExpiresAt: time.Now().Add(diskCacheTTLs[kind])})
+}
+
+// SetNegative remembers that key has no value, usually for less time than a positive entry
+func (c *DiskCache) SetNegative(kind DiskCacheKind, key string, ttl time.Duration) {
+	c.set(kind, key, diskCacheEntry{Negative: true, ExpiresAt: time.Now().Add(ttl)})
+}
+
+func (c *DiskCache) set(kind DiskCacheKind, key string, entry diskCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries, ok := c.entries[kind]
+	if !ok {
+		entries = make(map[string]diskCacheEntry)
+		c.entries[kind] = entries
+	}
+	entries[key] = entry
+	c.dirty = true
+	if limit := diskCacheLimits[kind]; limit > 0 && len(entries) > limit {
+		c.trim(entries, limit)
+	}
+}
+
+// trim drops the entries closest to expiry until the kind fits into its limit
+func (c *DiskCache) trim(entries map[string]diskCacheEntry, limit int) {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return entries[keys[i]].ExpiresAt.Before(entries[keys[j]].ExpiresAt)
+	})
+	for _, key := range keys[:len(keys)-limit] {
+		delete(entries, key)
+	}
+}
+
+// Flush writes the cache to disk if anything changed since the last flush
+func (c *DiskCache) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty || c.filename == "" {
+		return nil
+	}
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(c.filename), 0755)
+	if err != nil {
+		return err
+	}
+	tmp := c.filename + ".tmp"
+	err = os.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp, c.filename)
+	if err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+func (c *DiskCache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[DiskCacheKind]map[string]diskCacheEntry)
+	c.dirty = false
+	if c.filename == "" {
+		return nil
+	}
+	err := os.Remove(c.filename)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (c *DiskCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	total := 0
+	for _, entries := range c.entries {
+		total += len(entries)
+	}
+	return total
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDiskCacheRoundTrip(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cache.json")
+	c, err := loadDiskCache(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set(DiskCacheAppName, "730:english", "Counter-Strike 2")
+	c.Set(DiskCacheMarketNameID, "753-Cozy Cottage", "175880240")
+	c.SetNegative(DiskCacheMarketNameID, "753-Unlisted", time.Hour)
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filename + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	reopened, err := loadDiskCache(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, status := reopened.Get(DiskCacheAppName, "730:english"); status != CacheHit || value != "Counter-Strike 2" {
+		t.Errorf("app name = %q, %v after reload", value, status)
+	}
+	if value, status := reopened.Get(DiskCacheMarketNameID, "753-Cozy Cottage"); status != CacheHit || value != "175880240" {
+		t.Errorf("nameid = %q, %v after reload", value, status)
+	}
+	if _, status := reopened.Get(DiskCacheMarketNameID, "753-Unlisted"); status != CacheNegativeHit {
+		t.Errorf("negative entry = %v after reload, want a negative hit", status)
+	}
+	if reopened.Len() != 3 {
+		t.Errorf("Len() = %d after reload, want 3", reopened.Len())
+	}
+}
+
+func TestDiskCacheKindTTLs(t *testing.T) {
+	c, err := loadDiskCache(filepath.Join(t.TempDir(), "cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := time.Now()
+	c.Set(DiskCacheMarketPrice, "price", "{}")
+	c.Set(DiskCacheMarketNameID, "nameid", "1")
+	for kind, key := range map[DiskCacheKind]string{DiskCacheMarketPrice: "price", DiskCacheMarketNameID: "nameid"} {
+		expiresAt := c.entries[kind][key].ExpiresAt
+		if want := before.Add(diskCacheTTLs[kind]); expiresAt.Before(want) || expiresAt.After(want.Add(time.Minute)) {
+			t.Errorf("%s expires at %v, want about %v", kind, expiresAt, want)
+		}
+	}
+}
+
+func TestDiskCacheDropsExpiredEntriesOnLoad(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cache.json")
+	data, err := json.Marshal(map[DiskCacheKind]map[string]diskCacheEntry{
+		DiskCacheMarketPrice: {"stale": {Value: "{}", ExpiresAt: time.Now().Add(-time.Minute)}},
+		DiskCacheVanity:      {"gabelogannewell": {Value: "76561197960287930", ExpiresAt: time.Now().Add(time.Hour)}},
+		DiskCacheAppName:     nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := loadDiskCache(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want only the live vanity entry", c.Len())
+	}
+	if _, status := c.Get(DiskCacheMarketPrice, "stale"); status != CacheMiss {
+		t.Errorf("expired price = %v, want a miss", status)
+	}
+	c.Set(DiskCacheAppName, "730:english", "Counter-Strike 2")
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, _ := loadDiskCache(filename)
+	if reopened.Len() != 2 {
+		t.Errorf("Len() = %d after the flush, want 2", reopened.Len())
+	}
+}
+
+func TestDiskCacheCorruptFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cache.json")
+	if err := os.WriteFile(filename, []byte(`{"vanity":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := loadDiskCache(filename)
+	if err == nil {
+		t.Error("expected an error for a truncated cache file")
+	}
+	if c.Len() != 0 {
+		t.Errorf("corrupt cache loaded %d entries", c.Len())
+	}
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadDiskCache(filename); err != nil {
+		t.Errorf("the flush did not replace the corrupt file: %v", err)
+	}
+}
+
+func TestDiskCacheFailedFlushKeepsFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cache.json")
+	c, _ := loadDiskCache(filename)
+	c.Set(DiskCacheVanity, "old", "1")
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// A directory in place of the temporary file makes the write fail before the rename
+	if err := os.Mkdir(filename+".tmp", 0755); err != nil {
+		t.Fatal(err)
+	}
+	c.Set(DiskCacheVanity, "new", "2")
+	if err := c.Flush(); err == nil {
+		t.Fatal("expected the flush to fail")
+	}
+	reopened, err := loadDiskCache(filename)
+	if err != nil || reopened.Len() != 1 {
+		t.Errorf("cache.json after a failed flush has %d entries, %v, want the 1 of the last good flush", reopened.Len(), err)
+	}
+	if err := os.Remove(filename + ".tmp"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Flush(); err != nil {
+		t.Errorf("changes were not kept for the next flush: %v", err)
+	}
+	if reopened, _ := loadDiskCache(filename); reopened.Len() != 2 {
+		t.Errorf("cache.json has %d entries after the retried flush, want 2", reopened.Len())
+	}
+}
+
+func TestDiskCacheLimit(t *testing.T) {
+	limit := diskCacheLimits[DiskCacheVanity]
+	diskCacheLimits[DiskCacheVanity] = 2
+	defer func() { diskCacheLimits[DiskCacheVanity] = limit }()
+	c, _ := loadDiskCache(filepath.Join(t.TempDir(), "cache.json"))
+	c.SetNegative(DiskCacheVanity, "soonest", time.Minute)
+	c.Set(DiskCacheVanity, "a", "1")
+	c.Set(DiskCacheVanity, "b", "2")
+	if _, status := c.Get(DiskCacheVanity, "soonest"); status != CacheMiss {
+		t.Error("the entry closest to expiry survived the trim")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want the limit of 2", c.Len())
+	}
+}
@@ -1,13 +1,15 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 )
 
@@ -25,28 +27,35 @@ type itemData struct {
 }
 
 type itemDefinition struct {
-	Appid                 int      `json:"appid"`
-	Defid                 int      `json:"defid"`
-	Type                  int      `json:"type"`
-	CommunityItemClass    int      `json:"community_item_class"`
-	CommunityItemType     int      `json:"community_item_type"`
-	PointCost             string   `json:"point_cost"`
-	TimestampCreated      int      `json:"timestamp_created"`
-	TimestampUpdated      int      `json:"timestamp_updated"`
-	TimestampAvailable    int      `json:"timestamp_available"`
-	TimestampAvailableEnd int      `json:"timestamp_available_end"`
-	Quantity              string   `json:"quantity"`
-	InternalDescription   string   `json:"internal_description"`
-	Active                bool     `json:"active"`
-	CommunityItemData     itemData `json:"community_item_data"`
-	UsableDuration        int      `json:"usable_duration"`
-	BundleDiscount        int      `json:"bundle_discount"`
-	BundleDefids          []int    `json:"bundle_defids"`
+	Appid                 int                `json:"appid"`
+	Defid                 int                `json:"defid"`
+	Type                  int                `json:"type"`
+	CommunityItemClass    CommunityItemClass `json:"community_item_class"`
+	CommunityItemType     int                `json:"community_item_type"`
+	PointCost             string             `json:"point_cost"`
+	TimestampCreated      int                `json:"timestamp_created"`
+	TimestampUpdated      int                `json:"timestamp_updated"`
+	TimestampAvailable    int                `json:"timestamp_available"`
+	TimestampAvailableEnd int                `json:"timestamp_available_end"`
+	Quantity              string             `json:"quantity"`
+	InternalDescription   string             `json:"internal_description"`
+	Active                bool               `json:"active"`
+	CommunityItemData     itemData           `json:"community_item_data"`
+	UsableDuration        int                `json:"usable_duration"`
+	BundleDiscount        int                `json:"bundle_discount"`
+	BundleDefids          []int              `json:"bundle_defids"`
 }
 
 type equippedItemsResponse struct {
-	ActiveDefinitions   []itemDefinition `json:"active_definitions"`
-	InactiveDefinitions []itemDefinition `json:"inactive_definitions"`
+	ProfileBackground     equippedSlot     `json:"profile_background"`
+	MiniProfileBackground equippedSlot     `json:"mini_profile_background"`
+	AvatarFrame           equippedSlot     `json:"avatar_frame"`
+	AnimatedAvatar        equippedSlot     `json:"animated_avatar"`
+	ProfileModifier       equippedSlot     `json:"profile_modifier"`
+	KeyboardSkin          equippedSlot     `json:"steam_deck_keyboard_skin"`
+	StartupMovie          equippedSlot     `json:"steam_deck_startup_movie"`
+	ActiveDefinitions     []itemDefinition `json:"active_definitions"`
+	InactiveDefinitions   []itemDefinition `json:"inactive_definitions"`
 }
 
 type equippedItemsGlobalResponse struct {
@@ -54,54 +63,108 @@ type equippedItemsGlobalResponse struct {
 }
 
 type EquippedItem struct {
-	Appid               int    `json:"appid"`
-	CommunityItemClass  int    `json:"community_item_class"`
-	ItemName            string `json:"item_name"`
-	ItemTitle           string `json:"item_title"`
-	PointCost           string `json:"point_cost"`
-	ItemDescription     string `json:"item_description"`
-	Active              bool   `json:"active"`
-	InternalDescription string `json:"internal_description"`
-	Animated            bool   `json:"animated"`
-	IsActiveDefinition  bool   `json:"is_active_definition"`
-	ItemImageURI        string `json:"item_image_uri"`
-	ItemPointsURI       string `json:"item_points_uri"`
-	ItemMarketURI       string `json:"item_market_uri"`
-	ItemMarketID        int    `json:"item_market_id"`
-	ItemMarketPrice     string `json:"item_market_price"`
-}
-
-func GetEquippedItems(steam64ID string, language string) ([]EquippedItem, error) {
-	res, err := http.Get(fmt.Sprintf("https://api.steampowered.com/ILoyaltyRewardsService/GetEquippedProfileItems/v1?steamid=%s&language=%s", steam64ID, language))
+	Appid                  int                `json:"appid"`
+	Defid                  int                `json:"defid"`
+	Type                   int                `json:"type"`
+	CommunityItemClass     CommunityItemClass `json:"community_item_class"`
+	CommunityItemClassName string             `json:"community_item_class_name"`
+	CommunityItemType      int                `json:"community_item_type"`
+	CommunityItemID        string             `json:"community_item_id"`
+	ItemName               string             `json:"item_name"`
+	ItemTitle              string             `json:"item_title"`
+	PointCost              string             `json:"point_cost"`
+	ItemDescription        string             `json:"item_description"`
+	Active                 bool               `json:"active"`
+	InternalDescription    string             `json:"internal_description"`
+	Animated               bool               `json:"animated"`
+	IsActiveDefinition     bool               `json:"is_active_definition"`
+	TimestampCreated       int                `json:"timestamp_created"`
+	TimestampUpdated       int                `json:"timestamp_updated"`
+	TimestampAvailable     int                `json:"timestamp_available"`
+	TimestampAvailableEnd  int                `json:"timestamp_available_end"`
+	BundleDefids           []int              `json:"bundle_defids"`
+	ItemImageURI           string             `json:"item_image_uri"`
+	ItemPointsURI          string             `json:"item_points_uri"`
+	ItemMarketURI          string             `json:"item_market_uri"`
+	ItemMarketID           int                `json:"item_market_id"`
+	ItemMarketPrice        string             `json:"item_market_price"`
+	MarketPrice            *MarketPrice       `json:"market_price"`
+	MarketStatus           string             `json:"market_status"`
+}
+
+// MarketHashName is the name the item is listed under on the community market
+func (item EquippedItem) MarketHashName() string {
+	return fmt.Sprintf("%d-%s", item.Appid, item.ItemName)
+}
+
+// Key identifies an item definition across calls, e.g. for diffing loadouts or caching
+func (item EquippedItem) Key() string {
+	return fmt.Sprintf("%d-%d", item.Appid, item.Defid)
+}
+
+// sortEquippedItems orders items by slot, then active definitions first, then defid
+func sortEquippedItems(items []EquippedItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.CommunityItemClass != b.CommunityItemClass {
+			return a.CommunityItemClass < b.CommunityItemClass
+		}
+		if a.IsActiveDefinition != b.IsActiveDefinition {
+			return a.IsActiveDefinition
+		}
+		return a.Defid < b.Defid
+	})
+}
+
+const (
+	MarketStatusOK            = "ok"
+	MarketStatusNotMarketable = "not_marketable"
+	MarketStatusThrottled     = "throttled"
+	MarketStatusFailed        = "failed"
+)
+
+// marketFailureStatus turns the error of a market lookup into the status the frontend shows
+func marketFailureStatus(err error) string {
+	switch {
+	case errors.Is(err, ErrNotMarketable):
+		return MarketStatusNotMarketable
+	case errors.Is(err, ErrRateLimited):
+		return MarketStatusThrottled
+	}
+	return MarketStatusFailed
+}
+
+func getEquippedProfileItems(ctx context.Context, steam64ID string, language string) (equippedItemsResponse, error) {
+	body, err := steamClient.GetBody(ctx, fmt.Sprintf("https://api.steampowered.com/ILoyaltyRewardsService/GetEquippedProfileItems/v1?steamid=%s&language=%s", steam64ID, language))
 	if err != nil {
-		return nil, err
+		return equippedItemsResponse{}, err
 	}
-	defer res.Body.Close()
 	var response equippedItemsGlobalResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
+	err = json.Unmarshal(body, &response)
 	if err != nil {
-		return nil, err
+		return equippedItemsResponse{}, fmt.Errorf("failed to parse equipped items: %w: %w", ErrMarkupChanged, err)
 	}
-	activeItems := make([]EquippedItem, len(response.Response.ActiveDefinitions))
-	inactiveItems := make([]EquippedItem, len(response.Response.InactiveDefinitions))
-	eqItemCh := make(chan EquippedItem, len(activeItems)+len(inactiveItems))
-	defer close(eqItemCh)
-	for _, item := range response.Response.ActiveDefinitions {
-		go func(item itemDefinition, isActive bool) {
-			eqItemCh <- createEquippedItemFromDefinition(item, true)
-		}(item, true)
+	return response.Response, nil
+}
+
+func GetEquippedItems(ctx context.Context, steam64ID string, language string) ([]EquippedItem, error) {
+	response, err := getEquippedProfileItems(ctx, steam64ID, language)
+	if err != nil {
+		return nil, err
 	}
-	for _, item := range response.Response.InactiveDefinitions {
-		go func(item itemDefinition, isActive bool) {
-			eqItemCh <- createEquippedItemFromDefinition(item, false)
-		}(item, false)
+	return equippedItemsFromResponse(response), nil
+}
+
+func equippedItemsFromResponse(response equippedItemsResponse) []EquippedItem {
+	equippedItems := make([]EquippedItem, 0, len(response.ActiveDefinitions)+len(response.InactiveDefinitions))
+	for _, item := range response.ActiveDefinitions {
+		equippedItems = append(equippedItems, createEquippedItemFromDefinition(item, true))
 	}
-	equippedItems := make([]EquippedItem, 0, len(activeItems)+len(inactiveItems))
-	for i := 0; i < len(activeItems)+len(inactiveItems); i++ {
-		equippedItems = append(equippedItems, <-eqItemCh)
+	for _, item := range response.InactiveDefinitions {
+		equippedItems = append(equippedItems, createEquippedItemFromDefinition(item, false))
 	}
-
-	return equippedItems, nil
+	sortEquippedItems(equippedItems)
+	return equippedItems
 }
 
 func createEquippedItemFromDefinition(item itemDefinition, isActive bool) EquippedItem {
@@ -119,98 +182,198 @@ func createEquippedItemFromDefinition(item itemDefinition, isActive bool) Equipp
 	}
 
 	return EquippedItem{
-		Appid:               item.Appid,
-		CommunityItemClass:  item.CommunityItemClass,
-		ItemName:            item.CommunityItemData.ItemName,
-		ItemTitle:           item.CommunityItemData.ItemTitle,
-		PointCost:           item.PointCost,
-		ItemDescription:     item.CommunityItemData.ItemDescription,
-		Active:              item.Active,
-		InternalDescription: item.InternalDescription,
-		Animated:            item.CommunityItemData.Animated,
-		IsActiveDefinition:  isActive,
-		ItemImageURI:        itemImageURI,
-		ItemPointsURI:       fmt.Sprintf("https://store.steampowered.com/points/shop/app/%d/reward/%d/", item.Appid, item.Defid),
-		ItemMarketURI:       "",
-		ItemMarketID:        0,
-	}
-}
-
-func addMarketURIToEquippedItems(equippedItems []EquippedItem, currency int) []EquippedItem {
-	eqItemCh := make(chan EquippedItem, len(equippedItems))
+		Appid:                  item.Appid,
+		Defid:                  item.Defid,
+		Type:                   item.Type,
+		CommunityItemClass:     item.CommunityItemClass,
+		CommunityItemClassName: item.CommunityItemClass.String(),
+		CommunityItemType:      item.CommunityItemType,
+		TimestampCreated:       item.TimestampCreated,
+		TimestampUpdated:       item.TimestampUpdated,
+		TimestampAvailable:     item.TimestampAvailable,
+		TimestampAvailableEnd:  item.TimestampAvailableEnd,
+		BundleDefids:           item.BundleDefids,
+		ItemName:               item.CommunityItemData.ItemName,
+		ItemTitle:              item.CommunityItemData.ItemTitle,
+		PointCost:              item.PointCost,
+		ItemDescription:        item.CommunityItemData.ItemDescription,
+		Active:                 item.Active,
+		InternalDescription:    item.InternalDescription,
+		Animated:               item.CommunityItemData.Animated,
+		IsActiveDefinition:     isActive,
+		ItemImageURI:           itemImageURI,
+		ItemPointsURI:          fmt.Sprintf("https://store.steampowered.com/points/shop/app/%d/reward/%d/", item.Appid, item.Defid),
+		ItemMarketURI:          "",
+		ItemMarketID:           0,
+	}
+}
 
-	for i := range equippedItems {
-		go func(item EquippedItem) {
-			if item.ItemMarketURI == "" {
-				itemMarketURI := fmt.Sprintf("https://steamcommunity.com/market/listings/753/%d-%s", item.Appid, strings.ReplaceAll(item.ItemName, " ", "%20"))
-
-				client := &http.Client{Timeout: 10 * time.Second}
-				resp, err := client.Get(itemMarketURI)
-				if err != nil {
-					fmt.Printf("Error getting market URI for item %s: %v", item.ItemName, err)
-					item.ItemMarketURI = ""
-				} else {
-					defer resp.Body.Close()
-					body, err := io.ReadAll(resp.Body)
-					if err != nil {
-						fmt.Printf("Error reading response body for item %s: %v", item.ItemName, err)
-						item.ItemMarketURI = ""
-					} else {
-						bodyString := string(body)
-						re := regexp.MustCompile(`<div id="searchResultsTable"[\s\S]*?class="market_content_block market_home_listing_table market_home_main_listing_table market_listing_table">`)
-						if !re.MatchString(bodyString) {
-							item.ItemMarketURI = itemMarketURI
-							reNum := regexp.MustCompile(`Market_LoadOrderSpread\(\s*(\d+)\s*\);`)
-							match := reNum.FindStringSubmatch(bodyString)
-							if len(match) > 1 {
-								num, err := strconv.Atoi(match[1])
-								if err != nil {
-									fmt.Printf("Error parsing number from response body for item %s: %v", item.ItemName, err)
-								} else {
-									item.ItemMarketID = num
-									item = putMarketPriceToEquippedItem(item, currency)
-								}
-							}
-						} else {
-							item.ItemMarketURI = ""
-						}
-					}
-				}
+type marketOptions struct {
+	Currency int
+	Language string
+	Workers  int
+}
+
+// addMarketURIToEquippedItems looks every item up on the market using at most options.Workers concurrent lookups.
+// The returned slice keeps the order of equippedItems. progress, if set, is called as soon as each step of an item resolves.
+func addMarketURIToEquippedItems(ctx context.Context, equippedItems []EquippedItem, options marketOptions, cache *Cache, progress marketProgress) []EquippedItem {
+	if progress == nil {
+		progress = func(string, EquippedItem) {}
+	}
+	workers := options.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	equippedItemsWithMarketURI := make([]EquippedItem, len(equippedItems))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				equippedItemsWithMarketURI[i] = lookUpMarketItem(ctx, equippedItems[i], options, cache, progress)
 			}
-			eqItemCh <- item
-		}(equippedItems[i])
+		}()
 	}
-
-	equippedItemsWithMarketURI := make([]EquippedItem, 0, len(equippedItems))
-	for i := 0; i < len(equippedItems); i++ {
-		item := <-eqItemCh
-		equippedItemsWithMarketURI = append(equippedItemsWithMarketURI, item)
+	copy(equippedItemsWithMarketURI, equippedItems)
+dispatch:
+	for i := range equippedItems {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
 	}
+	close(jobs)
+	wg.Wait()
 
 	return equippedItemsWithMarketURI
 }
 
-func putMarketPriceToEquippedItem(item EquippedItem, currency int) EquippedItem {
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(fmt.Sprintf("https://steamcommunity.com/market/itemordershistogram?language=english&currency=%d&item_nameid=%d", currency, item.ItemMarketID))
+// lookUpMarketItem finds the listing and price of an item that has no market URI yet. A panic
+// only fails this item, the worker keeps going with the next one.
+func lookUpMarketItem(ctx context.Context, item EquippedItem, options marketOptions, cache *Cache, progress marketProgress) (result EquippedItem) {
+	result = item
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("Recovered from panic looking up %s on the market: %v\n", item.ItemName, r)
+			result = item
+			result.MarketStatus = MarketStatusFailed
+			progress(MarketEventFailed, result)
+		}
+	}()
+	if item.ItemMarketURI != "" {
+		return item
+	}
+	item = putMarketURIToEquippedItem(ctx, item, cache)
+	switch item.MarketStatus {
+	case MarketStatusOK:
+		progress(MarketEventURIFound, item)
+	case MarketStatusNotMarketable:
+		progress(MarketEventNotMarketable, item)
+	default:
+		progress(MarketEventFailed, item)
+	}
+	if item.ItemMarketID != 0 {
+		item = putMarketPriceToEquippedItem(ctx, item, options.Currency, options.Language, cache)
+		if item.MarketStatus == MarketStatusOK {
+			progress(MarketEventPriceFetched, item)
+		} else {
+			progress(MarketEventFailed, item)
+		}
+	}
+	return item
+}
+
+var (
+	// marketSearchResultsPattern matches the search results the market shows instead of a listing
+	// that doesn't exist
+	marketSearchResultsPattern = regexp.MustCompile(`<div id="searchResultsTable"[\s\S]*?class="market_content_block market_home_listing_table market_home_main_listing_table market_listing_table">`)
+	marketNameIDPattern        = regexp.MustCompile(`Market_LoadOrderSpread\(\s*(\d+)\s*\);`)
+)
+
+func marketListingURL(marketHashName string) string {
+	return fmt.Sprintf("https://steamcommunity.com/market/listings/%d/%s", steamCommunityAppID, url.PathEscape(marketHashName))
+}
+
+func marketListingNotFound(body []byte) bool {
+	return marketSearchResultsPattern.Match(body)
+}
+
+// putMarketURIToEquippedItem looks up the market listing of the item and its item_nameid.
+// Both the nameid and the fact that an item isn't marketable are remembered on disk, the price
+// history on the downloaded page is kept in memory for GetPriceHistory.
+func putMarketURIToEquippedItem(ctx context.Context, item EquippedItem, cache *Cache) EquippedItem {
+	itemMarketURI := marketListingURL(item.MarketHashName())
+	cacheKey := item.MarketHashName()
+	cachedNameID, status := cache.disk.Get(DiskCacheMarketNameID, cacheKey)
+	switch status {
+	case CacheHit:
+		if num, err := strconv.Atoi(cachedNameID); err == nil {
+			item.ItemMarketURI = itemMarketURI
+			item.ItemMarketID = num
+			item.MarketStatus = MarketStatusOK
+			return item
+		}
+	case CacheNegativeHit:
+		item.MarketStatus = MarketStatusNotMarketable
+		return item
+	}
+
+	body, err := steamClient.GetBody(ctx, itemMarketURI)
 	if err != nil {
-		fmt.Printf("Error making a request to steam market API for item %s: %v", item.ItemName, err)
-	} else {
-		defer resp.Body.Close()
-		body, err := io.ReadAll(resp.Body)
+		fmt.Printf("Error getting market URI for item %s: %v", item.ItemName, err)
+		item.MarketStatus = marketFailureStatus(err)
+		return item
+	}
+	if marketListingNotFound(body) {
+		cache.disk.SetNegative(DiskCacheMarketNameID, cacheKey, 24*time.Hour)
+		item.MarketStatus = MarketStatusNotMarketable
+		return item
+	}
+	item.ItemMarketURI = itemMarketURI
+	item.MarketStatus = MarketStatusOK
+	if _, err := cachePriceHistory(item.MarketHashName(), body, cache); err != nil {
+		fmt.Printf("Error parsing price history of item %s: %v", item.ItemName, err)
+	}
+	match := marketNameIDPattern.FindSubmatch(body)
+	if len(match) > 1 {
+		num, err := strconv.Atoi(string(match[1]))
 		if err != nil {
-			fmt.Printf("Error reading response body for item %s: %v", item.ItemName, err)
+			fmt.Printf("Error parsing number from response body for item %s: %v", item.ItemName, err)
 		} else {
-			bodyString := string(body)
-			regex := regexp.MustCompile(`<span class=\\"market_commodity_orders_header_promote\\">([^<]*)<\\/span>",`)
-			match := regex.FindStringSubmatch(bodyString)
-			if len(match) > 0 {
-				extractedString := match[1]
-				item.ItemMarketPrice = extractedString
-			} else {
-				fmt.Printf("No match found: %s", bodyString)
-			}
+			item.ItemMarketID = num
+			cache.disk.Set(DiskCacheMarketNameID, cacheKey, string(match[1]))
 		}
 	}
 	return item
 }
+
+// putMarketPriceToEquippedItem fills the structured market price of an item with a known
+// item_nameid. ItemMarketPrice keeps the lowest sell price as Steam formats it.
+func putMarketPriceToEquippedItem(ctx context.Context, item EquippedItem, currency int, language string, cache *Cache) EquippedItem {
+	cacheKey := fmt.Sprintf("%d:%d:%s", item.ItemMarketID, currency, language)
+	if cached, status := cache.disk.Get(DiskCacheMarketPrice, cacheKey); status == CacheHit {
+		var price MarketPrice
+		if err := json.Unmarshal([]byte(cached), &price); err == nil {
+			price.applyFees(communityMarketFees)
+			item.MarketPrice = &price
+			item.ItemMarketPrice = price.Formatted
+			return item
+		}
+	}
+	price, err := getMarketPrice(ctx, item.ItemMarketID, item.MarketHashName(), currency, language, cache)
+	if err != nil {
+		fmt.Printf("Error getting market price of item %s: %v", item.ItemName, err)
+		item.MarketStatus = marketFailureStatus(err)
+		return item
+	}
+	price.applyFees(communityMarketFees)
+	item.MarketPrice = &price
+	item.ItemMarketPrice = price.Formatted
+	if data, err := json.Marshal(price); err == nil {
+		cache.disk.Set(DiskCacheMarketPrice, cacheKey, string(data))
+	}
+	return item
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// Sentinel errors every fetcher wraps its failures with, callers test them with errors.Is
+var (
+	ErrInvalidAPIKey   = errors.New("API key not authorized to access Steam API")
+	ErrInvalidSteamID  = errors.New("invalid SteamID")
+	ErrProfileNotFound = errors.New("profile not found")
+	ErrPrivateProfile  = errors.New("profile is private")
+	ErrRateLimited     = errors.New("rate limited by Steam")
+	ErrMarkupChanged   = errors.New("unexpected response from Steam, the page or API may have changed")
+	ErrNetwork         = errors.New("network failure")
+	ErrNotMarketable   = errors.New("item is not marketable")
+	ErrInvalidCurrency = errors.New("unsupported currency")
+	ErrInvalidAmount   = errors.New("invalid amount")
+)
+
+// ErrorCode is what the frontend switches on, messages are only meant for humans
+type ErrorCode string
+
+const (
+	ErrorCodeInvalidAPIKey   ErrorCode = "invalid_api_key"
+	ErrorCodeInvalidInput    ErrorCode = "invalid_input"
+	ErrorCodeProfileNotFound ErrorCode = "profile_not_found"
+	ErrorCodePrivateProfile  ErrorCode = "private_profile"
+	ErrorCodeRateLimited     ErrorCode = "rate_limited"
+	ErrorCodeMarkupChanged   ErrorCode = "markup_changed"
+	ErrorCodeNetwork         ErrorCode = "network"
+	ErrorCodeNotMarketable   ErrorCode = "not_marketable"
+	ErrorCodeCancelled       ErrorCode = "cancelled"
+	ErrorCodeInternal        ErrorCode = "internal"
+	ErrorCodeUnknown         ErrorCode = "unknown"
+)
+
+// errorCodes is checked in order, the first sentinel found in the chain decides the code
+var errorCodes = []struct {
+	err  error
+	code ErrorCode
+}{
+	{context.Canceled, ErrorCodeCancelled},
+	{ErrInvalidAPIKey, ErrorCodeInvalidAPIKey},
+	{ErrInvalidSteamID, ErrorCodeInvalidInput},
+	{ErrInvalidCurrency, ErrorCodeInvalidInput},
+	{ErrInvalidAmount, ErrorCodeInvalidInput},
+	{ErrPrivateProfile, ErrorCodePrivateProfile},
+	{ErrProfileNotFound, ErrorCodeProfileNotFound},
+	{ErrNotMarketable, ErrorCodeNotMarketable},
+	{ErrRateLimited, ErrorCodeRateLimited},
+	{ErrMarkupChanged, ErrorCodeMarkupChanged},
+	{ErrNetwork, ErrorCodeNetwork},
+	{context.DeadlineExceeded, ErrorCodeNetwork},
+}
+
+func errorCodeOf(err error) ErrorCode {
+	var bindingErr *BindingError
+	if errors.As(err, &bindingErr) {
+		return bindingErr.Code
+	}
+	for _, candidate := range errorCodes {
+		if errors.Is(err, candidate.err) {
+			return candidate.code
+		}
+	}
+	return ErrorCodeUnknown
+}
+
+// BindingError is the error bindings hand to the frontend. Wails rejects the promise with the
+// message of the error, so the message is the JSON encoding of the struct.
+type BindingError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (e *BindingError) Error() string {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return e.Message
+	}
+	return string(data)
+}
+
+func newBindingError(err error) *BindingError {
+	var bindingErr *BindingError
+	if errors.As(err, &bindingErr) {
+		return bindingErr
+	}
+	return &BindingError{Code: errorCodeOf(err), Message: err.Error()}
+}
+
+// handleBindingError is deferred by every binding that returns an error. It turns the error into
+// a *BindingError and a panic into an internal error instead of letting it take the app down.
+func handleBindingError(err *error) {
+	if r := recover(); r != nil {
+		fmt.Printf("Recovered from panic in binding: %v\n%s", r, debug.Stack())
+		*err = &BindingError{Code: ErrorCodeInternal, Message: fmt.Sprint(r)}
+		return
+	}
+	if *err != nil {
+		*err = newBindingError(*err)
+	}
+}
+
+// recoverGoroutine is deferred at the top of background goroutines, they have no caller that
+// could receive an error
+func recoverGoroutine(name string) {
+	if r := recover(); r != nil {
+		fmt.Printf("Recovered from panic in %s: %v\n%s", name, r, debug.Stack())
+	}
+}
+
+// webAPIError turns the statuses the Web API rejects a key with into ErrInvalidAPIKey, other
+// errors are returned as is. None of them carries the key, the client drops the query of URLs.
+func webAPIError(err error) error {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && (statusErr.StatusCode == 401 || statusErr.StatusCode == 403) {
+		return ErrInvalidAPIKey
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorCode
+	}{
+		{ErrInvalidAPIKey, ErrorCodeInvalidAPIKey},
+		{fmt.Errorf("failed to resolve x: %w", errors.Join(fmt.Errorf("web_api: %w", ErrNetwork), ErrProfileNotFound)), ErrorCodeProfileNotFound},
+		{&StatusError{StatusCode: 429, URL: "https://steamcommunity.com/market/"}, ErrorCodeRateLimited},
+		{&StatusError{StatusCode: 500, URL: "https://steamcommunity.com/market/"}, ErrorCodeNetwork},
+		{webAPIError(&StatusError{StatusCode: 503}), ErrorCodeNetwork},
+		{&StatusError{StatusCode: 404, URL: "https://steamcommunity.com/market/"}, ErrorCodeUnknown},
+		{fmt.Errorf("inspection 1 was cancelled: %w", context.Canceled), ErrorCodeCancelled},
+		{fmt.Errorf("failed to parse app list: %w: %w", ErrMarkupChanged, errors.New("unexpected EOF")), ErrorCodeMarkupChanged},
+		{webAPIError(&StatusError{StatusCode: 403}), ErrorCodeInvalidAPIKey},
+		{errors.New("boom"), ErrorCodeUnknown},
+	}
+	for _, test := range tests {
+		if got := errorCodeOf(test.err); got != test.want {
+			t.Errorf("errorCodeOf(%v) = %s, want %s", test.err, got, test.want)
+		}
+	}
+}
+
+func TestHandleBindingError(t *testing.T) {
+	binding := func(fail error, panicValue any) (_ string, err error) {
+		defer handleBindingError(&err)
+		if panicValue != nil {
+			panic(panicValue)
+		}
+		return "", fail
+	}
+
+	if _, err := binding(nil, nil); err != nil {
+		t.Errorf("got %v for a successful call", err)
+	}
+	for _, test := range []struct {
+		fail       error
+		panicValue any
+		want       ErrorCode
+	}{
+		{fmt.Errorf("%w: %w", ErrInvalidSteamID, errors.New("empty SteamID")), nil, ErrorCodeInvalidInput},
+		{nil, "index out of range", ErrorCodeInternal},
+	} {
+		_, err := binding(test.fail, test.panicValue)
+		var decoded BindingError
+		if err == nil || json.Unmarshal([]byte(err.Error()), &decoded) != nil {
+			t.Fatalf("binding error %v is not JSON", err)
+		}
+		if decoded.Code != test.want || decoded.Message == "" {
+			t.Errorf("got %+v, want code %s", decoded, test.want)
+		}
+	}
+}
+
+func TestProfilePageError(t *testing.T) {
+	tests := []struct {
+		page string
+		want error
+	}{
+		{`<div class="error_ctn"><div id="message"><h3>The specified profile could not be found.</h3></div></div>`, ErrProfileNotFound},
+		{`<div class="profile_private_info">This profile is private.</div>`, ErrPrivateProfile},
+		{`<div class="profile_header"></div>`, nil},
+	}
+	for _, test := range tests {
+		doc, err := html.Parse(strings.NewReader(test.page))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := profilePageError(doc); !errors.Is(got, test.want) || (test.want == nil && got != nil) {
+			t.Errorf("profilePageError(%s) = %v, want %v", test.page, got, test.want)
+		}
+	}
+}
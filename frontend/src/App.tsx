@@ -4,30 +4,39 @@ import './App.css'
 import miniprofileCSS from './assets/steam/shared_global.css'
 import pointsIcon from './assets/steam/points-icon.svg'
 import {
-    AddMarketURIToEquippedItemsViaGolang,
     GetEquippedItemsViaGolang,
-    GetPageBodyViaGolang, GetSettings,
-    GetSteam32IDViaGolang, SaveAppSettings
+    GetPageBodyViaGolang, GetPlayerSummary, GetSettings,
+    GetSteam32IDViaGolang, SaveAppSettings, StartInspection, StartMarketEnrichment
 } from "../wailsjs/go/main/App";
-import React, {useEffect, useState} from "react";
+import {EventsOn} from "../wailsjs/runtime";
+import React, {useEffect, useRef, useState} from "react";
 import ApiKeyForm from "./components/ApiKeyForm";
 import SettingsModal from "./components/SettingsModal";
-import {openSteamLink, steam32to64} from "./util";
+import {openSteamLink, parseBindingError, steam32to64} from "./util";
 import {main} from "../wailsjs/go/models";
 import EquippedItem = main.EquippedItem;
 import AppSettings = main.AppSettings;
 
+type MarketItemEvent = {
+    inspection_id: string;
+    defid: number;
+    item: EquippedItem;
+}
+
 function App() {
     const domParser = new DOMParser();
-    const [settings, setSettings] = useState<AppSettings>({api_key:"", steam_currency: 1, open_links_in_steam: 2})
+    const [settings, setSettings] = useState<AppSettings>(AppSettings.createFrom({api_key:"", steam_currency: 1, open_links_in_steam: 2, language: "english"}))
     const [user32Id, setUser32Id] = useState<string>("Not set");
     const [equippedItems, setEquippedItems] = useState<Array<EquippedItem>>([]);
     const [apiInputStatus, setApiInputStatus] = useState<number>(0);
     const [profileInput, setProfileInput] = useState<string>("");
     const [miniprofile, setMiniprofile] = useState<string | JSX.Element | JSX.Element[]>();
+    const [profileStatus, setProfileStatus] = useState<string>("");
     const [isLoadingProfile, setLoadingProfile] = useState<boolean>(false);
     const [isLoadingItems, setLoadingItems] = useState<boolean>(false);
     const [showSettings, setShowSettings] = useState<boolean>(false);
+    const [settingsLoaded, setSettingsLoaded] = useState<boolean>(false);
+    const inspectionId = useRef<string>("");
 
     function handleProfileInputChange(event: React.ChangeEvent<HTMLInputElement>) {
         const inputValue = event.target.value;
@@ -37,8 +46,11 @@ function App() {
         }
     }
 
-    function fetchMiniProfile(id: string) {
-        GetPageBodyViaGolang(`https://steamcommunity.com/miniprofile/${id}.html`).then((result) => {
+    function fetchMiniProfile(inspection: string, id: string) {
+        GetPageBodyViaGolang(inspection, `https://steamcommunity.com/miniprofile/${id}.html`).then((result) => {
+            if (inspection !== inspectionId.current) {
+                return;
+            }
             const miniprofile = parser(domParser.parseFromString(result, "text/html").body.innerHTML, {});
             setMiniprofile(miniprofile);
         }).catch((err) => console.error(err)).finally(() => setLoadingProfile(false));
@@ -51,51 +63,90 @@ function App() {
         const matches = profileInput.match(/\/(id|profiles)\/(\d+|[a-zA-Z\d_-]+)/);
         const profileURI = matches && matches.length >= 3 ? matches[2] : profileInput;
 
-        const fetchProfileData = (profileURI: string, apiKey: string = "") => {
+        const fetchProfileData = async (profileURI: string, apiKey: string = "") => {
             setLoadingProfile(true);
             setMiniprofile("");
+            setProfileStatus("");
             setLoadingItems(true);
             setEquippedItems([]);
-            GetSteam32IDViaGolang(profileURI, apiKey).then(
+            const inspection = await StartInspection();
+            inspectionId.current = inspection;
+            const isCurrent = () => inspection === inspectionId.current;
+            GetSteam32IDViaGolang(inspection, profileURI, apiKey).then(
                 (result) => {
+                    if (!isCurrent()) {
+                        return;
+                    }
                     const user32Id = result;
                     setUser32Id(user32Id);
 
-                    GetEquippedItemsViaGolang(steam32to64(parseInt(user32Id)), "russian").then(
+                    GetPlayerSummary(inspection, [steam32to64(parseInt(user32Id))]).then((summaries) => {
+                        if (isCurrent() && summaries.length) {
+                            setProfileStatus(summaries[0].status);
+                        }
+                    }).catch((err) => console.error(err));
+
+                    GetEquippedItemsViaGolang(inspection, steam32to64(parseInt(user32Id)), settings.language).then(
                         (result) => {
+                            if (!isCurrent()) {
+                                return;
+                            }
                             setEquippedItems(result.sort((a, b) => a.community_item_class - b.community_item_class));
-                            AddMarketURIToEquippedItemsViaGolang(result, settings.steam_currency)
-                                .then((result) => {
-                                    const equippedItemsWithMarketURI = result.sort(
-                                        (a, b) => a.community_item_class - b.community_item_class
-                                    );
-                                    console.log(equippedItemsWithMarketURI);
-                                    setEquippedItems(equippedItemsWithMarketURI);
-                                })
-                                .catch((err) => console.error(err))
-                                .finally(() => setLoadingItems(false));
+                            StartMarketEnrichment(inspection, result, settings.steam_currency)
+                                .catch((err) => {
+                                    console.error(err);
+                                    if (isCurrent()) setLoadingItems(false);
+                                });
                         }
                     ).catch((err) => console.error(err));
 
-                    fetchMiniProfile(user32Id);
+                    fetchMiniProfile(inspection, user32Id);
+                }
+            ).catch((err) => {
+                const error = parseBindingError(err);
+                switch (error.code) {
+                    case "invalid_api_key":
+                        setSettings(prevSettings => AppSettings.createFrom({ ...prevSettings, api_key: '' }));
+                        setApiInputStatus(-1);
+                        fetchProfileData(profileURI);
+                        return;
+                    case "cancelled":
+                        return;
                 }
-            ).catch((err: string) => {
-                if (err === "API key not authorized to access Steam API") {
-                    setSettings(prevSettings => ({ ...prevSettings, api_key: '' }));
-                    setApiInputStatus(-1);
-                    fetchProfileData(profileURI);
-                } else {
+                console.error(error.message);
+                if (isCurrent()) {
                     setLoadingProfile(false);
                     setLoadingItems(false);
                 }
             }).finally(() => {
-                setLoadingProfile(false)
+                if (isCurrent()) setLoadingProfile(false)
             });
         };
 
         fetchProfileData(profileURI, settings.api_key);
     }
 
+    useEffect(() => {
+        const updateItem = (event: MarketItemEvent) => {
+            if (event.inspection_id !== inspectionId.current) {
+                return;
+            }
+            setEquippedItems(prevItems => prevItems.map(item => item.defid === event.defid ? event.item : item));
+        };
+        const unsubscribers = [
+            EventsOn("market:uri-found", updateItem),
+            EventsOn("market:price-fetched", updateItem),
+            EventsOn("market:not-marketable", updateItem),
+            EventsOn("market:failed", updateItem),
+            EventsOn("market:done", (summary: { inspection_id: string }) => {
+                if (summary.inspection_id === inspectionId.current) {
+                    setLoadingItems(false);
+                }
+            }),
+        ];
+        return () => unsubscribers.forEach(unsubscribe => unsubscribe());
+    }, []);
+
     useEffect(() => {
         const handleKeyDown = (event: KeyboardEvent) => {
             if (event.key === "Enter" && !isLoadingProfile) {
@@ -110,6 +161,9 @@ function App() {
     });
 
     useEffect(() => {
+        if (!settingsLoaded) {
+            return;
+        }
         SaveAppSettings(settings).catch(err => console.error(err))
     }, [settings]);
 
@@ -117,6 +171,7 @@ function App() {
         async function getSettings() {
             const result = await GetSettings();
             setSettings(result);
+            setSettingsLoaded(true);
         }
 
         getSettings();
@@ -159,6 +214,11 @@ function App() {
               Loading...</span>) : ("Inspect Profile")}
                 </button>
             </div>
+            {(profileStatus === "private" || profileStatus === "friends_only" || profileStatus === "not_set_up") && (
+                <div className="mt-2 px-4 py-2 rounded bg-yellow-100 text-yellow-900 text-sm font-medium">
+                    {profileStatus === "not_set_up" ? "This user has not set up their Steam Community profile" : "This profile is private"}
+                </div>
+            )}
             <root.div className="miniprofile_wrapper">
                 {(miniprofile as JSX.Element)}
                 <style type="text/css">{miniprofileCSS}</style>
@@ -210,7 +270,7 @@ function App() {
                                 if (item.item_market_uri) openSteamLink(item.item_market_uri, settings.open_links_in_steam)
                             }}
                                     className={"text-white rounded px-2 mx-1 uppercase inline-flex tracking-wide" + " " + (item.item_market_uri ? "bg-blue-500" : "bg-blue-300 cursor-not-allowed")}>
-                                {isLoadingItems ? (<span className={"inline-block"}><svg aria-hidden="true"
+                                {(isLoadingItems && !item.market_status) ? (<span className={"inline-block"}><svg aria-hidden="true"
                                                                                          role="status"
                                                                                          className="inline w-4 h-4 mr-3 text-white animate-spin"
                                                                                          viewBox="0 0 100 101"
@@ -223,7 +283,8 @@ function App() {
                 d="M93.9676 39.0409C96.393 38.4038 97.8624 35.9116 97.0079 33.5539C95.2932 28.8227 92.871 24.3692 89.8167 20.348C85.8452 15.1192 80.8826 10.7238 75.2124 7.41289C69.5422 4.10194 63.2754 1.94025 56.7698 1.05124C51.7666 0.367541 46.6976 0.446843 41.7345 1.27873C39.2613 1.69328 37.813 4.19778 38.4501 6.62326C39.0873 9.04874 41.5694 10.4717 44.0505 10.1071C47.8511 9.54855 51.7191 9.52689 55.5402 10.0491C60.8642 10.7766 65.9928 12.5457 70.6331 15.2552C75.2735 17.9648 79.3347 21.5619 82.5849 25.841C84.9175 28.9121 86.7997 32.2913 88.1811 35.8758C89.083 38.2158 91.5421 39.6781 93.9676 39.0409Z"
                 fill="currentColor"/>
           </svg>
-              Loading...</span>) : item.item_market_price ? (<span>{item.item_market_price}</span>) : (<span>Market</span>)}
+              Loading...</span>) : item.item_market_price ? (<span title={item.market_price?.formatted_receives ? "Buyer pays / seller receives" : undefined}>{item.item_market_price}{item.market_price?.formatted_receives && (
+                                    <> / {item.market_price.formatted_receives}</>)}</span>) : (<span>Market</span>)}
                             </button>
                         </div>
                     </div>
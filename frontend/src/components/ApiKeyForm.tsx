@@ -41,7 +41,7 @@ function ApiKeyForm({settings, setSettings, status, setStatus}: ApiKeyFormProps)
         if (!form.checkValidity()) {
             return;
         }
-        setSettings(prevSettings => ({ ...prevSettings, api_key: APIInputValue }));
+        setSettings(prevSettings => AppSettings.createFrom({ ...prevSettings, api_key: APIInputValue }));
         setStatus(1);
     };
 
@@ -1,7 +1,9 @@
-import React, {Dispatch, SetStateAction, useEffect} from 'react';
-import {STEAM_CURRENCIES, SteamCurrencyInfo} from "../constants";
+import React, {Dispatch, SetStateAction, useEffect, useState} from 'react';
 import {main} from "../../wailsjs/go/models";
+import {GetCurrencies, GetLanguages} from "../../wailsjs/go/main/App";
 import AppSettings = main.AppSettings;
+import SteamCurrency = main.SteamCurrency;
+import SteamLanguage = main.SteamLanguage;
 
 interface settingsProps {
     settings: AppSettings
@@ -11,6 +13,13 @@ interface settingsProps {
 }
 
 const SettingsModal = ({settings, setSettings, show, setShow}: settingsProps) => {
+    const [languages, setLanguages] = useState<SteamLanguage[]>([]);
+    const [currencies, setCurrencies] = useState<SteamCurrency[]>([]);
+
+    useEffect(() => {
+        GetLanguages().then(setLanguages).catch(err => console.error(err));
+        GetCurrencies().then(setCurrencies).catch(err => console.error(err));
+    }, []);
 
     const closeModal = (e: React.MouseEvent | MouseEvent) => {
         if(e.target instanceof HTMLElement && e.target.id==="settings-button") {
@@ -25,7 +34,7 @@ const SettingsModal = ({settings, setSettings, show, setShow}: settingsProps) =>
     }, []);
 
     function handleOptionChange(e: React.ChangeEvent<HTMLInputElement>) {
-        setSettings(prevSettings => ({
+        setSettings(prevSettings => AppSettings.createFrom({
             ...prevSettings,
             open_links_in_steam: Number(e.target.value)
         }));
@@ -33,15 +42,23 @@ const SettingsModal = ({settings, setSettings, show, setShow}: settingsProps) =>
 
     function handleCurrencyChange(event: React.ChangeEvent<HTMLSelectElement>) {
         const selectedCurrencyId = parseInt(event.target.value);
-        const selectedCurrency = STEAM_CURRENCIES.find(currency => currency.id === selectedCurrencyId);
+        const selectedCurrency = currencies.find(currency => currency.id === selectedCurrencyId);
         if (selectedCurrency) {
-            setSettings(prevSettings => ({
+            setSettings(prevSettings => AppSettings.createFrom({
                 ...prevSettings,
                 steam_currency: Number(selectedCurrency.id)
             }));
         }
     }
 
+    function handleLanguageChange(event: React.ChangeEvent<HTMLSelectElement>) {
+        const language = event.target.value;
+        setSettings(prevSettings => AppSettings.createFrom({
+            ...prevSettings,
+            language: language
+        }));
+    }
+
     return (
             <div className={"fixed z-10 left-1/2 top-1/2 transform -translate-x-1/2 w-max bg-white rounded-lg transition shadow-xl dark:bg-gray-700 " + (show ? "-translate-y-1/2" : "-translate-y-30/1")} onClick={(e) => e.stopPropagation()}>
                 <button type="button"
@@ -87,13 +104,24 @@ const SettingsModal = ({settings, setSettings, show, setShow}: settingsProps) =>
                     <label htmlFor="currency-dropdown"
                            className="block mb-2 text-sm font-medium text-gray-900 dark:text-white">Currency</label>
                     <select id={"currency-dropdown"} value={settings.steam_currency} onChange={handleCurrencyChange} className={"bg-gray-50 border border-gray-300 text-gray-900 text-sm rounded-lg focus:ring-blue-500 focus:border-blue-500 block w-full p-2.5 dark:bg-gray-700 dark:border-gray-600 dark:placeholder-gray-400 dark:text-white dark:focus:ring-blue-500 dark:focus:border-blue-500"}>
-                        {STEAM_CURRENCIES.map(currency => (
+                        {currencies.map(currency => (
                             <option key={currency.id} value={currency.id}>
                                 {currency.symbol} / {currency.name}
                             </option>
                         ))}
                     </select>
                 </div>
+                <div className="px-6 py-2 lg:px-8">
+                    <label htmlFor="language-dropdown"
+                           className="block mb-2 text-sm font-medium text-gray-900 dark:text-white">Item language</label>
+                    <select id={"language-dropdown"} value={settings.language} onChange={handleLanguageChange} className={"bg-gray-50 border border-gray-300 text-gray-900 text-sm rounded-lg focus:ring-blue-500 focus:border-blue-500 block w-full p-2.5 dark:bg-gray-700 dark:border-gray-600 dark:placeholder-gray-400 dark:text-white dark:focus:ring-blue-500 dark:focus:border-blue-500"}>
+                        {languages.map(language => (
+                            <option key={language.code} value={language.code}>
+                                {language.native_name} / {language.name}
+                            </option>
+                        ))}
+                    </select>
+                </div>
             </div>
     );
 };
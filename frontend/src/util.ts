@@ -13,4 +13,26 @@ export function openSteamLink(url: string, openLinksInSteam: number) {
         default:
             return OpenCustomURLViaGolang("steam://openurl/"+url, url, "steam");
     }
-}
\ No newline at end of file
+}
+export type BindingErrorCode =
+    "invalid_api_key" | "invalid_input" | "profile_not_found" | "private_profile" | "rate_limited" |
+    "markup_changed" | "network" | "not_marketable" | "cancelled" | "internal" | "unknown";
+
+export type BindingError = {
+    code: BindingErrorCode;
+    message: string;
+}
+
+// parseBindingError decodes the JSON the Go bindings reject their promises with
+export function parseBindingError(err: unknown): BindingError {
+    const message = typeof err === "string" ? err : String(err);
+    try {
+        const parsed = JSON.parse(message);
+        if (parsed && typeof parsed.code === "string") {
+            return {code: parsed.code, message: parsed.message ?? ""};
+        }
+    } catch {
+        // not an error produced by a binding
+    }
+    return {code: "unknown", message};
+}
@@ -2,26 +2,70 @@
 // This file is automatically generated. DO NOT EDIT
 import {main} from '../models';
 
-export function AddMarketURIToEquippedItemsViaGolang(arg1:Array<main.EquippedItem>,arg2:number):Promise<Array<main.EquippedItem>>;
+export function AddMarketURIToEquippedItemsViaGolang(arg1:string,arg2:Array<main.EquippedItem>,arg3:number):Promise<Array<main.EquippedItem>>;
+
+export function CancelInspection(arg1:string):Promise<void>;
+
+export function ClearCache():Promise<void>;
+
+export function GetAppDetails(arg1:string,arg2:number,arg3:string):Promise<main.AppDetails>;
 
 export function GetAppId(arg1:string):Promise<string>;
 
-export function GetBackground(arg1:string,arg2:main.Cache):Promise<string>;
+export function GetAppPrices(arg1:string,arg2:Array<number>,arg3:string):Promise<{[key: number]: main.AppPriceOverview}>;
+
+export function GetBackground(arg1:string,arg2:string):Promise<main.ProfileBackground>;
+
+export function GetBadges(arg1:string,arg2:string):Promise<main.ProfileBadges>;
+
+export function GetCacheStats():Promise<main.CacheStatsByStore>;
+
+export function GetCurrencies():Promise<Array<main.SteamCurrency>>;
+
+export function GetEquippedItemsViaGolang(arg1:string,arg2:string,arg3:string):Promise<Array<main.EquippedItem>>;
+
+export function GetGameName(arg1:string,arg2:string,arg3:string):Promise<main.GameName>;
+
+export function GetLanguages():Promise<Array<main.SteamLanguage>>;
+
+export function GetLoadoutValue(arg1:string,arg2:Array<main.EquippedItem>):Promise<main.LoadoutValue>;
 
-export function GetEquippedItemsViaGolang(arg1:string,arg2:string):Promise<Array<main.EquippedItem>>;
+export function GetOrderBook(arg1:string,arg2:number,arg3:number):Promise<main.OrderBook>;
 
-export function GetGameName(arg1:string,arg2:main.Cache):Promise<string>;
+export function GetPageBodyViaGolang(arg1:string,arg2:string):Promise<string>;
 
-export function GetPageBodyViaGolang(arg1:string):Promise<string>;
+export function GetPlayerSummary(arg1:string,arg2:Array<string>):Promise<Array<main.PlayerSummary>>;
+
+export function GetPriceHistory(arg1:string,arg2:string):Promise<main.PriceHistory>;
+
+export function GetProfileLoadout(arg1:string,arg2:string,arg3:string):Promise<main.ProfileLoadout>;
+
+export function GetProfilePage(arg1:string,arg2:string,arg3:string):Promise<main.ProfilePage>;
 
 export function GetSettings():Promise<main.AppSettings>;
 
-export function GetSteam32IDViaGolang(arg1:string,arg2:string):Promise<string>;
+export function GetShowcases(arg1:string,arg2:string):Promise<Array<main.Showcase>>;
+
+export function GetSteam32IDViaGolang(arg1:string,arg2:string,arg3:string):Promise<string>;
 
 export function Greet(arg1:string):Promise<string>;
 
+export function MarketFeesForBuyerPays(arg1:number):Promise<main.MarketFees>;
+
+export function MarketFeesForSellerReceives(arg1:number):Promise<main.MarketFees>;
+
 export function OpenCustomURLViaGolang(arg1:string,arg2:string,arg3:string):Promise<void>;
 
-export function PutMarketPriceToEquippedItemViaGolang(arg1:main.EquippedItem,arg2:number):Promise<main.EquippedItem>;
+export function PutMarketPriceToEquippedItemViaGolang(arg1:string,arg2:main.EquippedItem,arg3:number):Promise<main.EquippedItem>;
+
+export function RefreshAppCatalog():Promise<void>;
+
+export function ResolveVanityURL(arg1:string,arg2:string,arg3:string):Promise<main.VanityResolution>;
 
 export function SaveAppSettings(arg1:main.AppSettings):Promise<void>;
+
+export function SearchApps(arg1:string,arg2:number):Promise<Array<main.AppCatalogEntry>>;
+
+export function StartInspection():Promise<string>;
+
+export function StartMarketEnrichment(arg1:string,arg2:Array<main.EquippedItem>,arg3:number):Promise<void>;
@@ -2,50 +2,138 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function AddMarketURIToEquippedItemsViaGolang(arg1, arg2) {
-  return window['go']['main']['App']['AddMarketURIToEquippedItemsViaGolang'](arg1, arg2);
+export function AddMarketURIToEquippedItemsViaGolang(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AddMarketURIToEquippedItemsViaGolang'](arg1, arg2, arg3);
+}
+
+export function CancelInspection(arg1) {
+  return window['go']['main']['App']['CancelInspection'](arg1);
+}
+
+export function ClearCache() {
+  return window['go']['main']['App']['ClearCache']();
+}
+
+export function GetAppDetails(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetAppDetails'](arg1, arg2, arg3);
 }
 
 export function GetAppId(arg1) {
   return window['go']['main']['App']['GetAppId'](arg1);
 }
 
+export function GetAppPrices(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetAppPrices'](arg1, arg2, arg3);
+}
+
 export function GetBackground(arg1, arg2) {
   return window['go']['main']['App']['GetBackground'](arg1, arg2);
 }
 
-export function GetEquippedItemsViaGolang(arg1, arg2) {
-  return window['go']['main']['App']['GetEquippedItemsViaGolang'](arg1, arg2);
+export function GetBadges(arg1, arg2) {
+  return window['go']['main']['App']['GetBadges'](arg1, arg2);
+}
+
+export function GetCacheStats() {
+  return window['go']['main']['App']['GetCacheStats']();
+}
+
+export function GetCurrencies() {
+  return window['go']['main']['App']['GetCurrencies']();
+}
+
+export function GetEquippedItemsViaGolang(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetEquippedItemsViaGolang'](arg1, arg2, arg3);
+}
+
+export function GetGameName(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetGameName'](arg1, arg2, arg3);
+}
+
+export function GetLanguages() {
+  return window['go']['main']['App']['GetLanguages']();
+}
+
+export function GetLoadoutValue(arg1, arg2) {
+  return window['go']['main']['App']['GetLoadoutValue'](arg1, arg2);
+}
+
+export function GetOrderBook(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetOrderBook'](arg1, arg2, arg3);
 }
 
-export function GetGameName(arg1, arg2) {
-  return window['go']['main']['App']['GetGameName'](arg1, arg2);
+export function GetPageBodyViaGolang(arg1, arg2) {
+  return window['go']['main']['App']['GetPageBodyViaGolang'](arg1, arg2);
 }
 
-export function GetPageBodyViaGolang(arg1) {
-  return window['go']['main']['App']['GetPageBodyViaGolang'](arg1);
+export function GetPlayerSummary(arg1, arg2) {
+  return window['go']['main']['App']['GetPlayerSummary'](arg1, arg2);
+}
+
+export function GetPriceHistory(arg1, arg2) {
+  return window['go']['main']['App']['GetPriceHistory'](arg1, arg2);
+}
+
+export function GetProfileLoadout(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetProfileLoadout'](arg1, arg2, arg3);
+}
+
+export function GetProfilePage(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetProfilePage'](arg1, arg2, arg3);
 }
 
 export function GetSettings() {
   return window['go']['main']['App']['GetSettings']();
 }
 
-export function GetSteam32IDViaGolang(arg1, arg2) {
-  return window['go']['main']['App']['GetSteam32IDViaGolang'](arg1, arg2);
+export function GetShowcases(arg1, arg2) {
+  return window['go']['main']['App']['GetShowcases'](arg1, arg2);
+}
+
+export function GetSteam32IDViaGolang(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetSteam32IDViaGolang'](arg1, arg2, arg3);
 }
 
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
 
+export function MarketFeesForBuyerPays(arg1) {
+  return window['go']['main']['App']['MarketFeesForBuyerPays'](arg1);
+}
+
+export function MarketFeesForSellerReceives(arg1) {
+  return window['go']['main']['App']['MarketFeesForSellerReceives'](arg1);
+}
+
 export function OpenCustomURLViaGolang(arg1, arg2, arg3) {
   return window['go']['main']['App']['OpenCustomURLViaGolang'](arg1, arg2, arg3);
 }
 
-export function PutMarketPriceToEquippedItemViaGolang(arg1, arg2) {
-  return window['go']['main']['App']['PutMarketPriceToEquippedItemViaGolang'](arg1, arg2);
+export function PutMarketPriceToEquippedItemViaGolang(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PutMarketPriceToEquippedItemViaGolang'](arg1, arg2, arg3);
+}
+
+export function RefreshAppCatalog() {
+  return window['go']['main']['App']['RefreshAppCatalog']();
+}
+
+export function ResolveVanityURL(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ResolveVanityURL'](arg1, arg2, arg3);
 }
 
 export function SaveAppSettings(arg1) {
   return window['go']['main']['App']['SaveAppSettings'](arg1);
 }
+
+export function SearchApps(arg1, arg2) {
+  return window['go']['main']['App']['SearchApps'](arg1, arg2);
+}
+
+export function StartInspection() {
+  return window['go']['main']['App']['StartInspection']();
+}
+
+export function StartMarketEnrichment(arg1, arg2, arg3) {
+  return window['go']['main']['App']['StartMarketEnrichment'](arg1, arg2, arg3);
+}
@@ -1,9 +1,161 @@
 export namespace main {
 	
+	export class AppCatalogEntry {
+	    appid: number;
+	    name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppCatalogEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.appid = source["appid"];
+	        this.name = source["name"];
+	    }
+	}
+	export class AppCategory {
+	    id: number;
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppCategory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.description = source["description"];
+	    }
+	}
+	export class AppPlatforms {
+	    windows: boolean;
+	    mac: boolean;
+	    linux: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppPlatforms(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.windows = source["windows"];
+	        this.mac = source["mac"];
+	        this.linux = source["linux"];
+	    }
+	}
+	export class AppPriceOverview {
+	    currency: string;
+	    initial: number;
+	    final: number;
+	    discount_percent: number;
+	    initial_formatted: string;
+	    final_formatted: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppPriceOverview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currency = source["currency"];
+	        this.initial = source["initial"];
+	        this.final = source["final"];
+	        this.discount_percent = source["discount_percent"];
+	        this.initial_formatted = source["initial_formatted"];
+	        this.final_formatted = source["final_formatted"];
+	    }
+	}
+	export class AppReleaseDate {
+	    coming_soon: boolean;
+	    date: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppReleaseDate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.coming_soon = source["coming_soon"];
+	        this.date = source["date"];
+	    }
+	}
+	export class AppDetails {
+	    steam_appid: number;
+	    type: string;
+	    name: string;
+	    is_free: boolean;
+	    header_image: string;
+	    developers: string[];
+	    publishers: string[];
+	    release_date: AppReleaseDate;
+	    price_overview?: AppPriceOverview;
+	    platforms: AppPlatforms;
+	    categories: AppCategory[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AppDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steam_appid = source["steam_appid"];
+	        this.type = source["type"];
+	        this.name = source["name"];
+	        this.is_free = source["is_free"];
+	        this.header_image = source["header_image"];
+	        this.developers = source["developers"];
+	        this.publishers = source["publishers"];
+	        this.release_date = this.convertValues(source["release_date"], AppReleaseDate);
+	        this.price_overview = this.convertValues(source["price_overview"], AppPriceOverview);
+	        this.platforms = this.convertValues(source["platforms"], AppPlatforms);
+	        this.categories = this.convertValues(source["categories"], AppCategory);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	export class HostRateLimit {
+	    host: string;
+	    requests_per_minute: number;
+	    burst: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HostRateLimit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.requests_per_minute = source["requests_per_minute"];
+	        this.burst = source["burst"];
+	    }
+	}
 	export class AppSettings {
 	    api_key: string;
 	    open_links_in_steam: number;
 	    steam_currency: number;
+	    host_rate_limits: HostRateLimit[];
+	    market_workers: number;
+	    language: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new AppSettings(source);
@@ -14,12 +166,284 @@ export namespace main {
 	        this.api_key = source["api_key"];
 	        this.open_links_in_steam = source["open_links_in_steam"];
 	        this.steam_currency = source["steam_currency"];
+	        this.host_rate_limits = this.convertValues(source["host_rate_limits"], HostRateLimit);
+	        this.market_workers = source["market_workers"];
+	        this.language = source["language"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AvatarURLs {
+	    full: string;
+	    medium: string;
+	    small: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AvatarURLs(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.full = source["full"];
+	        this.medium = source["medium"];
+	        this.small = source["small"];
+	    }
+	}
+	export class BackgroundMedia {
+	    image: string;
+	    mp4: string;
+	    webm: string;
+	    poster: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackgroundMedia(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.image = source["image"];
+	        this.mp4 = source["mp4"];
+	        this.webm = source["webm"];
+	        this.poster = source["poster"];
 	    }
 	}
+	export class Badge {
+	    badge_id: number;
+	    appid: number;
+	    name: string;
+	    game_name: string;
+	    level: number;
+	    foil: boolean;
+	    xp: number;
+	    completion_time: number;
+	    scarcity: number;
+	    image: string;
 	
+	    static createFrom(source: any = {}) {
+	        return new Badge(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.badge_id = source["badge_id"];
+	        this.appid = source["appid"];
+	        this.name = source["name"];
+	        this.game_name = source["game_name"];
+	        this.level = source["level"];
+	        this.foil = source["foil"];
+	        this.xp = source["xp"];
+	        this.completion_time = source["completion_time"];
+	        this.scarcity = source["scarcity"];
+	        this.image = source["image"];
+	    }
+	}
+	export class CacheStats {
+	    entries: number;
+	    hits: number;
+	    misses: number;
+	    evictions: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CacheStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.entries = source["entries"];
+	        this.hits = source["hits"];
+	        this.misses = source["misses"];
+	        this.evictions = source["evictions"];
+	    }
+	}
+	export class CacheStatsByStore {
+	    backgrounds: CacheStats;
+	    games: CacheStats;
+	    price_histories: CacheStats;
+	    order_books: CacheStats;
+	    disk: CacheStats;
+	    apps: CacheStats;
+	
+	    static createFrom(source: any = {}) {
+	        return new CacheStatsByStore(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.backgrounds = this.convertValues(source["backgrounds"], CacheStats);
+	        this.games = this.convertValues(source["games"], CacheStats);
+	        this.price_histories = this.convertValues(source["price_histories"], CacheStats);
+	        this.order_books = this.convertValues(source["order_books"], CacheStats);
+	        this.disk = this.convertValues(source["disk"], CacheStats);
+	        this.apps = this.convertValues(source["apps"], CacheStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CommunityProfileXML {
+	    steam_id64: string;
+	    persona_name: string;
+	    privacy_state: string;
+	    visibility_state: number;
+	    privacy_message: string;
+	    online_state: string;
+	    in_game_name: string;
+	    avatar_icon: string;
+	    avatar_medium: string;
+	    avatar_full: string;
+	    member_since: string;
+	    // Go type: time
+	    member_since_time: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommunityProfileXML(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steam_id64 = source["steam_id64"];
+	        this.persona_name = source["persona_name"];
+	        this.privacy_state = source["privacy_state"];
+	        this.visibility_state = source["visibility_state"];
+	        this.privacy_message = source["privacy_message"];
+	        this.online_state = source["online_state"];
+	        this.in_game_name = source["in_game_name"];
+	        this.avatar_icon = source["avatar_icon"];
+	        this.avatar_medium = source["avatar_medium"];
+	        this.avatar_full = source["avatar_full"];
+	        this.member_since = source["member_since"];
+	        this.member_since_time = this.convertValues(source["member_since_time"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MarketFees {
+	    buyer_pays: number;
+	    steam_fee: number;
+	    publisher_fee: number;
+	    seller_receives: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarketFees(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.buyer_pays = source["buyer_pays"];
+	        this.steam_fee = source["steam_fee"];
+	        this.publisher_fee = source["publisher_fee"];
+	        this.seller_receives = source["seller_receives"];
+	    }
+	}
+	export class MarketPrice {
+	    currency: number;
+	    lowest_sell: number;
+	    highest_buy: number;
+	    median: number;
+	    volume: number;
+	    sell_orders: number;
+	    buy_orders: number;
+	    price_prefix: string;
+	    price_suffix: string;
+	    formatted: string;
+	    lowest_sell_fees?: MarketFees;
+	    highest_buy_fees?: MarketFees;
+	    formatted_receives: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarketPrice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currency = source["currency"];
+	        this.lowest_sell = source["lowest_sell"];
+	        this.highest_buy = source["highest_buy"];
+	        this.median = source["median"];
+	        this.volume = source["volume"];
+	        this.sell_orders = source["sell_orders"];
+	        this.buy_orders = source["buy_orders"];
+	        this.price_prefix = source["price_prefix"];
+	        this.price_suffix = source["price_suffix"];
+	        this.formatted = source["formatted"];
+	        this.lowest_sell_fees = this.convertValues(source["lowest_sell_fees"], MarketFees);
+	        this.highest_buy_fees = this.convertValues(source["highest_buy_fees"], MarketFees);
+	        this.formatted_receives = source["formatted_receives"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class EquippedItem {
 	    appid: number;
+	    defid: number;
+	    type: number;
 	    community_item_class: number;
+	    community_item_class_name: string;
+	    community_item_type: number;
+	    community_item_id: string;
 	    item_name: string;
 	    item_title: string;
 	    point_cost: string;
@@ -28,11 +452,18 @@ export namespace main {
 	    internal_description: string;
 	    animated: boolean;
 	    is_active_definition: boolean;
+	    timestamp_created: number;
+	    timestamp_updated: number;
+	    timestamp_available: number;
+	    timestamp_available_end: number;
+	    bundle_defids: number[];
 	    item_image_uri: string;
 	    item_points_uri: string;
 	    item_market_uri: string;
 	    item_market_id: number;
 	    item_market_price: string;
+	    market_price?: MarketPrice;
+	    market_status: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new EquippedItem(source);
@@ -41,7 +472,12 @@ export namespace main {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.appid = source["appid"];
+	        this.defid = source["defid"];
+	        this.type = source["type"];
 	        this.community_item_class = source["community_item_class"];
+	        this.community_item_class_name = source["community_item_class_name"];
+	        this.community_item_type = source["community_item_type"];
+	        this.community_item_id = source["community_item_id"];
 	        this.item_name = source["item_name"];
 	        this.item_title = source["item_title"];
 	        this.point_cost = source["point_cost"];
@@ -50,12 +486,732 @@ export namespace main {
 	        this.internal_description = source["internal_description"];
 	        this.animated = source["animated"];
 	        this.is_active_definition = source["is_active_definition"];
+	        this.timestamp_created = source["timestamp_created"];
+	        this.timestamp_updated = source["timestamp_updated"];
+	        this.timestamp_available = source["timestamp_available"];
+	        this.timestamp_available_end = source["timestamp_available_end"];
+	        this.bundle_defids = source["bundle_defids"];
 	        this.item_image_uri = source["item_image_uri"];
 	        this.item_points_uri = source["item_points_uri"];
 	        this.item_market_uri = source["item_market_uri"];
 	        this.item_market_id = source["item_market_id"];
 	        this.item_market_price = source["item_market_price"];
+	        this.market_price = this.convertValues(source["market_price"], MarketPrice);
+	        this.market_status = source["market_status"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GameName {
+	    steam_appid: number;
+	    name: string;
+	    details?: AppDetails;
+	
+	    static createFrom(source: any = {}) {
+	        return new GameName(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steam_appid = source["steam_appid"];
+	        this.name = source["name"];
+	        this.details = this.convertValues(source["details"], AppDetails);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class LoadoutItemValue {
+	    appid: number;
+	    defid: number;
+	    community_item_class: number;
+	    community_item_class_name: string;
+	    item_title: string;
+	    point_cost: number;
+	    in_points_shop: boolean;
+	    marketable: boolean;
+	    market_value: number;
+	    market_fees?: MarketFees;
+	    source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadoutItemValue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.appid = source["appid"];
+	        this.defid = source["defid"];
+	        this.community_item_class = source["community_item_class"];
+	        this.community_item_class_name = source["community_item_class_name"];
+	        this.item_title = source["item_title"];
+	        this.point_cost = source["point_cost"];
+	        this.in_points_shop = source["in_points_shop"];
+	        this.marketable = source["marketable"];
+	        this.market_value = source["market_value"];
+	        this.market_fees = this.convertValues(source["market_fees"], MarketFees);
+	        this.source = source["source"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LoadoutSlotValue {
+	    community_item_class: number;
+	    community_item_class_name: string;
+	    items: LoadoutItemValue[];
+	    point_cost: number;
+	    market_value: number;
+	    seller_receives: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadoutSlotValue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.community_item_class = source["community_item_class"];
+	        this.community_item_class_name = source["community_item_class_name"];
+	        this.items = this.convertValues(source["items"], LoadoutItemValue);
+	        this.point_cost = source["point_cost"];
+	        this.market_value = source["market_value"];
+	        this.seller_receives = source["seller_receives"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LoadoutValue {
+	    currency: number;
+	    slots: LoadoutSlotValue[];
+	    point_cost: number;
+	    market_value: number;
+	    steam_fee: number;
+	    publisher_fee: number;
+	    seller_receives: number;
+	    replicate_points: number;
+	    replicate_cost: number;
+	    unavailable: LoadoutItemValue[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadoutValue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currency = source["currency"];
+	        this.slots = this.convertValues(source["slots"], LoadoutSlotValue);
+	        this.point_cost = source["point_cost"];
+	        this.market_value = source["market_value"];
+	        this.steam_fee = source["steam_fee"];
+	        this.publisher_fee = source["publisher_fee"];
+	        this.seller_receives = source["seller_receives"];
+	        this.replicate_points = source["replicate_points"];
+	        this.replicate_cost = source["replicate_cost"];
+	        this.unavailable = this.convertValues(source["unavailable"], LoadoutItemValue);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class OrderBookLevel {
+	    price: number;
+	    quantity: number;
+	    cumulative: number;
+	    aggregate: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new OrderBookLevel(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.price = source["price"];
+	        this.quantity = source["quantity"];
+	        this.cumulative = source["cumulative"];
+	        this.aggregate = source["aggregate"];
+	    }
+	}
+	export class OrderBook {
+	    name_id: number;
+	    currency: number;
+	    highest_buy: number;
+	    lowest_sell: number;
+	    buy_graph: OrderBookLevel[];
+	    sell_graph: OrderBookLevel[];
+	    buy_table: OrderBookLevel[];
+	    sell_table: OrderBookLevel[];
+	    graph_min_x: number;
+	    graph_max_x: number;
+	    graph_max_y: number;
+	    price_prefix: string;
+	    price_suffix: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OrderBook(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name_id = source["name_id"];
+	        this.currency = source["currency"];
+	        this.highest_buy = source["highest_buy"];
+	        this.lowest_sell = source["lowest_sell"];
+	        this.buy_graph = this.convertValues(source["buy_graph"], OrderBookLevel);
+	        this.sell_graph = this.convertValues(source["sell_graph"], OrderBookLevel);
+	        this.buy_table = this.convertValues(source["buy_table"], OrderBookLevel);
+	        this.sell_table = this.convertValues(source["sell_table"], OrderBookLevel);
+	        this.graph_min_x = source["graph_min_x"];
+	        this.graph_max_x = source["graph_max_x"];
+	        this.graph_max_y = source["graph_max_y"];
+	        this.price_prefix = source["price_prefix"];
+	        this.price_suffix = source["price_suffix"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PlayerSummary {
+	    steam_id: string;
+	    persona_name: string;
+	    persona_state: number;
+	    persona_state_name: string;
+	    visibility: number;
+	    profile_set_up: boolean;
+	    status: string;
+	    profile_url: string;
+	    avatar_hash: string;
+	    avatar: AvatarURLs;
+	    time_created: number;
+	    last_logoff: number;
+	    country_code: string;
+	    game_id: string;
+	    game_name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlayerSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steam_id = source["steam_id"];
+	        this.persona_name = source["persona_name"];
+	        this.persona_state = source["persona_state"];
+	        this.persona_state_name = source["persona_state_name"];
+	        this.visibility = source["visibility"];
+	        this.profile_set_up = source["profile_set_up"];
+	        this.status = source["status"];
+	        this.profile_url = source["profile_url"];
+	        this.avatar_hash = source["avatar_hash"];
+	        this.avatar = this.convertValues(source["avatar"], AvatarURLs);
+	        this.time_created = source["time_created"];
+	        this.last_logoff = source["last_logoff"];
+	        this.country_code = source["country_code"];
+	        this.game_id = source["game_id"];
+	        this.game_name = source["game_name"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PriceHistoryWindow {
+	    days: number;
+	    average: number;
+	    change: number;
+	    volume: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PriceHistoryWindow(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.days = source["days"];
+	        this.average = source["average"];
+	        this.change = source["change"];
+	        this.volume = source["volume"];
+	    }
+	}
+	export class PriceHistoryPoint {
+	    timestamp: number;
+	    median: number;
+	    volume: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PriceHistoryPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.timestamp = source["timestamp"];
+	        this.median = source["median"];
+	        this.volume = source["volume"];
+	    }
+	}
+	export class PriceHistory {
+	    market_hash_name: string;
+	    currency: number;
+	    price_prefix: string;
+	    price_suffix: string;
+	    points: PriceHistoryPoint[];
+	    windows: PriceHistoryWindow[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PriceHistory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.market_hash_name = source["market_hash_name"];
+	        this.currency = source["currency"];
+	        this.price_prefix = source["price_prefix"];
+	        this.price_suffix = source["price_suffix"];
+	        this.points = this.convertValues(source["points"], PriceHistoryPoint);
+	        this.windows = this.convertValues(source["windows"], PriceHistoryWindow);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class ProfileBackground {
+	    profile: BackgroundMedia;
+	    mini_profile: BackgroundMedia;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileBackground(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profile = this.convertValues(source["profile"], BackgroundMedia);
+	        this.mini_profile = this.convertValues(source["mini_profile"], BackgroundMedia);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProfileBadges {
+	    steam_id: string;
+	    level: number;
+	    xp: number;
+	    xp_needed_to_level_up: number;
+	    xp_needed_current_level: number;
+	    badges: Badge[];
+	    featured?: Badge;
+	    source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileBadges(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steam_id = source["steam_id"];
+	        this.level = source["level"];
+	        this.xp = source["xp"];
+	        this.xp_needed_to_level_up = source["xp_needed_to_level_up"];
+	        this.xp_needed_current_level = source["xp_needed_current_level"];
+	        this.badges = this.convertValues(source["badges"], Badge);
+	        this.featured = this.convertValues(source["featured"], Badge);
+	        this.source = source["source"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProfileLoadout {
+	    steam_id: string;
+	    profile_background?: EquippedItem;
+	    mini_profile_background?: EquippedItem;
+	    avatar_frame?: EquippedItem;
+	    animated_avatar?: EquippedItem;
+	    profile_modifier?: EquippedItem;
+	    keyboard_skin?: EquippedItem;
+	    startup_movie?: EquippedItem;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileLoadout(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steam_id = source["steam_id"];
+	        this.profile_background = this.convertValues(source["profile_background"], EquippedItem);
+	        this.mini_profile_background = this.convertValues(source["mini_profile_background"], EquippedItem);
+	        this.avatar_frame = this.convertValues(source["avatar_frame"], EquippedItem);
+	        this.animated_avatar = this.convertValues(source["animated_avatar"], EquippedItem);
+	        this.profile_modifier = this.convertValues(source["profile_modifier"], EquippedItem);
+	        this.keyboard_skin = this.convertValues(source["keyboard_skin"], EquippedItem);
+	        this.startup_movie = this.convertValues(source["startup_movie"], EquippedItem);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProfilePage {
+	    steam_id: string;
+	    persona_name: string;
+	    level: number;
+	    country_code: string;
+	    country_flag: string;
+	    avatar: AvatarURLs;
+	    avatar_frame: string;
+	    animated_avatar: string;
+	    background: BackgroundMedia;
+	    loadout: ProfileLoadout;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfilePage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steam_id = source["steam_id"];
+	        this.persona_name = source["persona_name"];
+	        this.level = source["level"];
+	        this.country_code = source["country_code"];
+	        this.country_flag = source["country_flag"];
+	        this.avatar = this.convertValues(source["avatar"], AvatarURLs);
+	        this.avatar_frame = source["avatar_frame"];
+	        this.animated_avatar = source["animated_avatar"];
+	        this.background = this.convertValues(source["background"], BackgroundMedia);
+	        this.loadout = this.convertValues(source["loadout"], ProfileLoadout);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ShowcaseItem {
+	    appid: number;
+	    context_id: string;
+	    asset_id: string;
+	    class_id: string;
+	    instance_id: string;
+	    image: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShowcaseItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.appid = source["appid"];
+	        this.context_id = source["context_id"];
+	        this.asset_id = source["asset_id"];
+	        this.class_id = source["class_id"];
+	        this.instance_id = source["instance_id"];
+	        this.image = source["image"];
+	    }
+	}
+	export class Showcase {
+	    kind: string;
+	    title: string;
+	    items: ShowcaseItem[];
+	    appids: number[];
+	    published_file_ids: string[];
+	    images: string[];
+	    text: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Showcase(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.title = source["title"];
+	        this.items = this.convertValues(source["items"], ShowcaseItem);
+	        this.appids = source["appids"];
+	        this.published_file_ids = source["published_file_ids"];
+	        this.images = source["images"];
+	        this.text = source["text"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SteamCurrency {
+	    id: number;
+	    code: string;
+	    symbol: string;
+	    name: string;
+	    decimals: number;
+	    placement: string;
+	    symbol_separator: string;
+	    decimal_separator: string;
+	    thousands_separator: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SteamCurrency(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.code = source["code"];
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.decimals = source["decimals"];
+	        this.placement = source["placement"];
+	        this.symbol_separator = source["symbol_separator"];
+	        this.decimal_separator = source["decimal_separator"];
+	        this.thousands_separator = source["thousands_separator"];
+	    }
+	}
+	export class SteamLanguage {
+	    code: string;
+	    name: string;
+	    native_name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SteamLanguage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.name = source["name"];
+	        this.native_name = source["native_name"];
+	    }
+	}
+	export class VanityResolution {
+	    vanity: string;
+	    steam_id: string;
+	    strategy: string;
+	    profile?: CommunityProfileXML;
+	
+	    static createFrom(source: any = {}) {
+	        return new VanityResolution(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.vanity = source["vanity"];
+	        this.steam_id = source["steam_id"];
+	        this.strategy = source["strategy"];
+	        this.profile = this.convertValues(source["profile"], CommunityProfileXML);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 
 }
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// inspections tracks the contexts of profile inspections started by the frontend.
+// Starting a new inspection cancels every request of the previous one.
+type inspections struct {
+	mu      sync.Mutex
+	nextID  int
+	cancels map[string]context.CancelFunc
+	ctxs    map[string]context.Context
+}
+
+func newInspections() *inspections {
+	return &inspections{
+		cancels: make(map[string]context.CancelFunc),
+		ctxs:    make(map[string]context.Context),
+	}
+}
+
+func (i *inspections) start(parent context.Context) string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for id, cancel := range i.cancels {
+		cancel()
+		delete(i.cancels, id)
+		delete(i.ctxs, id)
+	}
+	i.nextID++
+	id := strconv.Itoa(i.nextID)
+	ctx, cancel := context.WithCancel(parent)
+	i.ctxs[id] = ctx
+	i.cancels[id] = cancel
+	return id
+}
+
+func (i *inspections) cancel(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if cancel, ok := i.cancels[id]; ok {
+		cancel()
+		delete(i.cancels, id)
+		delete(i.ctxs, id)
+	}
+}
+
+func (i *inspections) context(id string) (context.Context, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	ctx, ok := i.ctxs[id]
+	return ctx, ok
+}
+
+// StartInspection cancels the running inspection and returns the id of a new one
+func (a *App) StartInspection() string {
+	return a.inspections.start(a.ctx)
+}
+
+func (a *App) CancelInspection(id string) {
+	a.inspections.cancel(id)
+}
+
+// contextFor returns the context requests of the inspection have to run with.
+// An empty id means the call isn't part of an inspection and only ends with the app.
+func (a *App) contextFor(inspectionID string) (context.Context, error) {
+	if inspectionID == "" {
+		return a.ctx, nil
+	}
+	ctx, ok := a.inspections.context(inspectionID)
+	if !ok {
+		return nil, fmt.Errorf("inspection %s was cancelled: %w", inspectionID, context.Canceled)
+	}
+	return ctx, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import "strings"
+
+const defaultLanguage = "english"
+
+type SteamLanguage struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	NativeName string `json:"native_name"`
+}
+
+// steamLanguages are the API language codes Steam accepts in language= and l= parameters
+var steamLanguages = []SteamLanguage{
+	{Code: "arabic", Name: "Arabic", NativeName: "العربية"},
+	{Code: "bulgarian", Name: "Bulgarian", NativeName: "български език"},
+	{Code: "schinese", Name: "Chinese (Simplified)", NativeName: "简体中文"},
+	{Code: "tchinese", Name: "Chinese (Traditional)", NativeName: "繁體中文"},
+	{Code: "czech", Name: "Czech", NativeName: "čeština"},
+	{Code: "danish", Name: "Danish", NativeName: "Dansk"},
+	{Code: "dutch", Name: "Dutch", NativeName: "Nederlands"},
+	{Code: "english", Name: "English", NativeName: "English"},
+	{Code: "finnish", Name: "Finnish", NativeName: "Suomi"},
+	{Code: "french", Name: "French", NativeName: "Français"},
+	{Code: "german", Name: "German", NativeName: "Deutsch"},
+	{Code: "greek", Name: "Greek", NativeName: "Ελληνικά"},
+	{Code: "hungarian", Name: "Hungarian", NativeName: "Magyar"},
+	{Code: "indonesian", Name: "Indonesian", NativeName: "Bahasa Indonesia"},
+	{Code: "italian", Name: "Italian", NativeName: "Italiano"},
+	{Code: "japanese", Name: "Japanese", NativeName: "日本語"},
+	{Code: "koreana", Name: "Korean", NativeName: "한국어"},
+	{Code: "norwegian", Name: "Norwegian", NativeName: "Norsk"},
+	{Code: "polish", Name: "Polish", NativeName: "Polski"},
+	{Code: "portuguese", Name: "Portuguese", NativeName: "Português"},
+	{Code: "brazilian", Name: "Portuguese (Brazil)", NativeName: "Português-Brasil"},
+	{Code: "romanian", Name: "Romanian", NativeName: "Română"},
+	{Code: "russian", Name: "Russian", NativeName: "Русский"},
+	{Code: "spanish", Name: "Spanish (Spain)", NativeName: "Español-España"},
+	{Code: "latam", Name: "Spanish (Latin America)", NativeName: "Español-Latinoamérica"},
+	{Code: "swedish", Name: "Swedish", NativeName: "Svenska"},
+	{Code: "thai", Name: "Thai", NativeName: "ไทย"},
+	{Code: "turkish", Name: "Turkish", NativeName: "Türkçe"},
+	{Code: "ukrainian", Name: "Ukrainian", NativeName: "Українська"},
+	{Code: "vietnamese", Name: "Vietnamese", NativeName: "Tiếng Việt"},
+}
+
+func isSupportedLanguage(code string) bool {
+	for _, language := range steamLanguages {
+		if language.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeLanguage returns the Steam language code for code, falling back to english
+func normalizeLanguage(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if isSupportedLanguage(code) {
+		return code
+	}
+	return defaultLanguage
+}
+
+func (a *App) GetLanguages() []SteamLanguage {
+	return steamLanguages
+}
+
+// languageFor picks the requested language if it is supported and the configured one otherwise
+func (a *App) languageFor(requested string) string {
+	requested = strings.ToLower(strings.TrimSpace(requested))
+	if isSupportedLanguage(requested) {
+		return requested
+	}
+	return normalizeLanguage(a.currentSettings().Language)
+}
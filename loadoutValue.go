@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// LoadoutItemSource is the way an item can be acquired today
+type LoadoutItemSource string
+
+const (
+	LoadoutItemSourcePointsShop  LoadoutItemSource = "points_shop"
+	LoadoutItemSourceMarket      LoadoutItemSource = "market"
+	LoadoutItemSourceUnavailable LoadoutItemSource = "unavailable"
+)
+
+// LoadoutItemValue is what one equipped item costs in points and on the market. MarketValue is
+// the lowest sell price, 0 and MarketFees nil when the item has no sell listing to price it by.
+type LoadoutItemValue struct {
+	Appid                  int                `json:"appid"`
+	Defid                  int                `json:"defid"`
+	CommunityItemClass     CommunityItemClass `json:"community_item_class"`
+	CommunityItemClassName string             `json:"community_item_class_name"`
+	ItemTitle              string             `json:"item_title"`
+	PointCost              int64              `json:"point_cost"`
+	InPointsShop           bool               `json:"in_points_shop"`
+	Marketable             bool               `json:"marketable"`
+	MarketValue            int64              `json:"market_value"`
+	MarketFees             *MarketFees        `json:"market_fees"`
+	Source                 LoadoutItemSource  `json:"source"`
+}
+
+// LoadoutSlotValue sums the items of one slot
+type LoadoutSlotValue struct {
+	CommunityItemClass     CommunityItemClass `json:"community_item_class"`
+	CommunityItemClassName string             `json:"community_item_class_name"`
+	Items                  []LoadoutItemValue `json:"items"`
+	PointCost              int64              `json:"point_cost"`
+	MarketValue            int64              `json:"market_value"`
+	SellerReceives         int64              `json:"seller_receives"`
+}
+
+// LoadoutValue is the worth of a loadout, market amounts in hundredths of Currency.
+// PointCost and MarketValue total every item sold that way, while ReplicatePoints and
+// ReplicateCost only count the cheapest source of each item: the points shop when it still
+// sells the item and the market otherwise.
+type LoadoutValue struct {
+	Currency        int                `json:"currency"`
+	Slots           []LoadoutSlotValue `json:"slots"`
+	PointCost       int64              `json:"point_cost"`
+	MarketValue     int64              `json:"market_value"`
+	SteamFee        int64              `json:"steam_fee"`
+	PublisherFee    int64              `json:"publisher_fee"`
+	SellerReceives  int64              `json:"seller_receives"`
+	ReplicatePoints int64              `json:"replicate_points"`
+	ReplicateCost   int64              `json:"replicate_cost"`
+	Unavailable     []LoadoutItemValue `json:"unavailable"`
+}
+
+// inPointsShop reports whether the points shop sells item at now
+func inPointsShop(item EquippedItem, pointCost int64, now time.Time) bool {
+	if pointCost <= 0 || !item.Active {
+		return false
+	}
+	return item.TimestampAvailableEnd == 0 || int64(item.TimestampAvailableEnd) > now.Unix()
+}
+
+func loadoutItemValue(item EquippedItem, currency int, now time.Time) LoadoutItemValue {
+	pointCost, _ := strconv.ParseInt(item.PointCost, 10, 64)
+	value := LoadoutItemValue{
+		Appid:                  item.Appid,
+		Defid:                  item.Defid,
+		CommunityItemClass:     item.CommunityItemClass,
+		CommunityItemClassName: item.CommunityItemClass.String(),
+		ItemTitle:              item.ItemTitle,
+		PointCost:              pointCost,
+		InPointsShop:           inPointsShop(item, pointCost, now),
+		Marketable:             item.MarketStatus == MarketStatusOK && item.ItemMarketID != 0,
+	}
+	if value.ItemTitle == "" {
+		value.ItemTitle = item.ItemName
+	}
+	if price := item.MarketPrice; price != nil && price.Currency == currency && price.LowestSell > 0 {
+		value.MarketValue = price.LowestSell
+		fees := communityMarketFees.ForBuyerPays(price.LowestSell)
+		value.MarketFees = &fees
+	}
+	switch {
+	case value.InPointsShop:
+		value.Source = LoadoutItemSourcePointsShop
+	case value.MarketValue > 0:
+		value.Source = LoadoutItemSourceMarket
+	default:
+		value.Source = LoadoutItemSourceUnavailable
+	}
+	return value
+}
+
+// calculateLoadoutValue breaks the value of items down per slot. Items need their market price
+// in currency already, see priceLoadoutItems.
+func calculateLoadoutValue(items []EquippedItem, currency int, now time.Time) LoadoutValue {
+	result := LoadoutValue{Currency: currency, Slots: []LoadoutSlotValue{}, Unavailable: []LoadoutItemValue{}}
+	sorted := append([]EquippedItem(nil), items...)
+	sortEquippedItems(sorted)
+	for _, item := range sorted {
+		value := loadoutItemValue(item, currency, now)
+		if len(result.Slots) == 0 || result.Slots[len(result.Slots)-1].CommunityItemClass != item.CommunityItemClass {
+			result.Slots = append(result.Slots, LoadoutSlotValue{
+				CommunityItemClass:     item.CommunityItemClass,
+				CommunityItemClassName: item.CommunityItemClass.String(),
+				Items:                  []LoadoutItemValue{},
+			})
+		}
+		slot := &result.Slots[len(result.Slots)-1]
+		slot.Items = append(slot.Items, value)
+
+		if value.InPointsShop {
+			slot.PointCost += value.PointCost
+			result.PointCost += value.PointCost
+		}
+		slot.MarketValue += value.MarketValue
+		result.MarketValue += value.MarketValue
+		if fees := value.MarketFees; fees != nil {
+			slot.SellerReceives += fees.SellerReceives
+			result.SteamFee += fees.SteamFee
+			result.PublisherFee += fees.PublisherFee
+			result.SellerReceives += fees.SellerReceives
+		}
+		switch value.Source {
+		case LoadoutItemSourcePointsShop:
+			result.ReplicatePoints += value.PointCost
+		case LoadoutItemSourceMarket:
+			result.ReplicateCost += value.MarketValue
+		default:
+			result.Unavailable = append(result.Unavailable, value)
+		}
+	}
+	return result
+}
+
+// priceLoadoutItems looks up the market price in options.Currency of every item that doesn't
+// have one yet. Items without a name, the ones only known from the profile page, are skipped.
+func priceLoadoutItems(ctx context.Context, items []EquippedItem, options marketOptions, cache *Cache) []EquippedItem {
+	var named []int
+	var lookups []EquippedItem
+	for i, item := range items {
+		if item.ItemName != "" {
+			named = append(named, i)
+			lookups = append(lookups, item)
+		}
+	}
+	lookups = addMarketURIToEquippedItems(ctx, lookups, options, cache, nil)
+	priced := append([]EquippedItem(nil), items...)
+	for i, item := range lookups {
+		if item.ItemMarketID != 0 && (item.MarketPrice == nil || item.MarketPrice.Currency != options.Currency) {
+			item = putMarketPriceToEquippedItem(ctx, item, options.Currency, options.Language, cache)
+		}
+		priced[named[i]] = item
+	}
+	return priced
+}
+
+// GetLoadoutValue prices items in the configured currency and breaks their value down per slot
+func (a *App) GetLoadoutValue(inspectionID string, items []EquippedItem) (_ LoadoutValue, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return LoadoutValue{}, err
+	}
+	currency := a.currentSettings().SteamCurrency
+	items = priceLoadoutItems(ctx, items, a.marketOptions(currency), a.cache)
+	if ctx.Err() != nil {
+		return LoadoutValue{}, ctx.Err()
+	}
+	return calculateLoadoutValue(items, currency, time.Now()), nil
+}
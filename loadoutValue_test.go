@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCalculateLoadoutValue(t *testing.T) {
+	now := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+	items := []EquippedItem{
+		{
+			Appid: 2861720, Defid: 183, CommunityItemClass: CommunityItemClassAvatarFrame,
+			ItemName: "Cozy Cottage Frame", PointCost: "2000", Active: true, MarketStatus: MarketStatusNotMarketable,
+		},
+		{
+			Appid: 570, Defid: 0, CommunityItemClass: CommunityItemClassProfileBackground,
+			ItemName: "Aghanim's Sanctum", ItemTitle: "Aghanim's Sanctum", MarketStatus: MarketStatusOK, ItemMarketID: 175880240,
+			MarketPrice: &MarketPrice{Currency: 1, LowestSell: 100, HighestBuy: 90},
+		},
+		{
+			Appid: 2861720, Defid: 185, CommunityItemClass: CommunityItemClassMiniProfileBackground,
+			ItemName: "Winter Sale Mini", PointCost: "500", Active: true, TimestampAvailableEnd: int(now.Add(-time.Hour).Unix()),
+			MarketStatus: MarketStatusNotMarketable,
+		},
+		{
+			Appid: 2861720, Defid: 190, CommunityItemClass: CommunityItemClassAnimatedAvatar,
+			ItemName: "Priced In Euro", PointCost: "3000", Active: true, MarketStatus: MarketStatusOK, ItemMarketID: 42,
+			MarketPrice: &MarketPrice{Currency: 3, LowestSell: 250},
+		},
+	}
+	checkGolden(t, "loadout", "loadout_value", calculateLoadoutValue(items, 1, now))
+}
+
+func TestBuildProfileLoadout(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "loadout", "equipped_items.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var response equippedItemsGlobalResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatal(err)
+	}
+	loadout := buildProfileLoadout(NewIndividualSteamID(22202), response.Response)
+
+	// The background slot holds a Dota 2 item; the points shop background of the same class must not take its id
+	if got := loadout.ProfileBackground; got == nil || got.Appid != 570 || got.Defid != 0 || got.CommunityItemID != "31052877413" {
+		t.Errorf("profile background = %+v, want the Dota 2 slot item", got)
+	}
+	// The frame slot matches the second frame definition by item type, not the first one of its class
+	if got := loadout.AvatarFrame; got == nil || got.Defid != 183 || got.CommunityItemID != "31052877500" {
+		t.Errorf("avatar frame = %+v, want defid 183 with the slot's item id", got)
+	}
+	checkGolden(t, "loadout", "equipped_items", loadout)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Events emitted to the frontend while equipped items are enriched with market data
+const (
+	MarketEventURIFound      = "market:uri-found"
+	MarketEventPriceFetched  = "market:price-fetched"
+	MarketEventNotMarketable = "market:not-marketable"
+	MarketEventFailed        = "market:failed"
+	MarketEventDone          = "market:done"
+)
+
+// MarketItemEvent is the payload of every per-item market event
+type MarketItemEvent struct {
+	InspectionID string       `json:"inspection_id"`
+	Defid        int          `json:"defid"`
+	Item         EquippedItem `json:"item"`
+}
+
+type MarketSummaryEvent struct {
+	InspectionID  string `json:"inspection_id"`
+	Total         int    `json:"total"`
+	Priced        int    `json:"priced"`
+	NotMarketable int    `json:"not_marketable"`
+	Throttled     int    `json:"throttled"`
+	Failed        int    `json:"failed"`
+	Cancelled     bool   `json:"cancelled"`
+}
+
+// marketProgress receives the name of a market event and the item it is about
+type marketProgress func(event string, item EquippedItem)
+
+func summarizeMarketResults(inspectionID string, items []EquippedItem) MarketSummaryEvent {
+	summary := MarketSummaryEvent{InspectionID: inspectionID, Total: len(items)}
+	for _, item := range items {
+		switch item.MarketStatus {
+		case MarketStatusOK:
+			if item.MarketPrice != nil {
+				summary.Priced++
+			}
+		case MarketStatusNotMarketable:
+			summary.NotMarketable++
+		case MarketStatusThrottled:
+			summary.Throttled++
+		case MarketStatusFailed:
+			summary.Failed++
+		}
+	}
+	return summary
+}
+
+// emitMarketProgress forwards market progress of an inspection to the frontend
+func (a *App) emitMarketProgress(inspectionID string) marketProgress {
+	return func(event string, item EquippedItem) {
+		wailsruntime.EventsEmit(a.ctx, event, MarketItemEvent{InspectionID: inspectionID, Defid: item.Defid, Item: item})
+	}
+}
+
+// StartMarketEnrichment looks up market data of items in the background and reports every
+// result through market:* events, finishing with market:done
+func (a *App) StartMarketEnrichment(inspectionID string, items []EquippedItem, currency int) (err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return err
+	}
+	if err := validateCurrency(currency); err != nil {
+		return err
+	}
+	options := a.marketOptions(currency)
+	go func(ctx context.Context) {
+		defer recoverGoroutine("market enrichment")
+		enriched := addMarketURIToEquippedItems(ctx, items, options, a.cache, a.emitMarketProgress(inspectionID))
+		summary := summarizeMarketResults(inspectionID, enriched)
+		summary.Cancelled = ctx.Err() != nil
+		wailsruntime.EventsEmit(a.ctx, MarketEventDone, summary)
+	}(ctx)
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// MarketFeeSchedule is the wallet fee info Steam's economy scripts compute market fees with.
+// Amounts are hundredths of whatever currency the price is in, Steam keeps every currency in
+// hundredths and applies the same minimums to all of them.
+type MarketFeeSchedule struct {
+	SteamFeePercent     float64 `json:"steam_fee_percent"`
+	SteamFeeMinimum     int64   `json:"steam_fee_minimum"`
+	SteamFeeBase        int64   `json:"steam_fee_base"`
+	PublisherFeePercent float64 `json:"publisher_fee_percent"`
+}
+
+// communityMarketFees is the schedule of community items, listed under appid 753 with a 10%
+// publisher fee on top of Steam's 5%
+var communityMarketFees = MarketFeeSchedule{
+	SteamFeePercent:     0.05,
+	SteamFeeMinimum:     1,
+	PublisherFeePercent: 0.10,
+}
+
+// MarketFees splits what a buyer pays into Steam's fee, the publisher's fee and what the seller receives
+type MarketFees struct {
+	BuyerPays      int64 `json:"buyer_pays"`
+	SteamFee       int64 `json:"steam_fee"`
+	PublisherFee   int64 `json:"publisher_fee"`
+	SellerReceives int64 `json:"seller_receives"`
+}
+
+// ForSellerReceives adds the fees on top of what the seller receives, like
+// CalculateAmountToSendForDesiredReceivedAmount in Steam's economy scripts. The publisher fee
+// is at least one hundredth whenever the app charges one.
+func (s MarketFeeSchedule) ForSellerReceives(received int64) MarketFees {
+	steamFee := int64(math.Floor(math.Max(float64(received)*s.SteamFeePercent, float64(s.SteamFeeMinimum)))) + s.SteamFeeBase
+	var publisherFee int64
+	if s.PublisherFeePercent > 0 {
+		publisherFee = int64(math.Floor(math.Max(float64(received)*s.PublisherFeePercent, 1)))
+	}
+	return MarketFees{
+		BuyerPays:      received + steamFee + publisherFee,
+		SteamFee:       steamFee,
+		PublisherFee:   publisherFee,
+		SellerReceives: received,
+	}
+}
+
+// ForBuyerPays splits a buyer price like CalculateFeeAmount does: it estimates what the seller
+// receives and walks it by one until the fees add up to amount. Steam keeps the remainder when
+// no amount adds up exactly. Amounts below MinimumPrice go to Steam entirely.
+func (s MarketFeeSchedule) ForBuyerPays(amount int64) MarketFees {
+	if amount < s.MinimumPrice() {
+		return MarketFees{BuyerPays: amount, SteamFee: amount}
+	}
+	received := int64(float64(amount-s.SteamFeeBase) / (s.SteamFeePercent + s.PublisherFeePercent + 1))
+	undershot := false
+	fees := s.ForSellerReceives(received)
+	for iterations := 0; fees.BuyerPays != amount && iterations < 10; iterations++ {
+		if fees.BuyerPays > amount {
+			if undershot {
+				fees = s.ForSellerReceives(received - 1)
+				fees.SteamFee += amount - fees.BuyerPays
+				fees.BuyerPays = amount
+				break
+			}
+			received--
+		} else {
+			undershot = true
+			received++
+		}
+		fees = s.ForSellerReceives(received)
+	}
+	return fees
+}
+
+// MinimumPrice is the lowest price an item can be listed at, one hundredth for the seller plus the minimum fees
+func (s MarketFeeSchedule) MinimumPrice() int64 {
+	return s.ForSellerReceives(1).BuyerPays
+}
+
+// MarketFeesForBuyerPays splits a community market price into the fees and what the seller
+// receives. Prices below MinimumPrice can't be listed and are rejected.
+func (a *App) MarketFeesForBuyerPays(amount int64) (_ MarketFees, err error) {
+	defer handleBindingError(&err)
+	if minimum := communityMarketFees.MinimumPrice(); amount < minimum {
+		return MarketFees{}, fmt.Errorf("%w: a buyer pays at least %d hundredths, not %d", ErrInvalidAmount, minimum, amount)
+	}
+	return communityMarketFees.ForBuyerPays(amount), nil
+}
+
+// MarketFeesForSellerReceives returns what a buyer pays for the seller to receive amount, at least one hundredth
+func (a *App) MarketFeesForSellerReceives(amount int64) (_ MarketFees, err error) {
+	defer handleBindingError(&err)
+	if amount < 1 {
+		return MarketFees{}, fmt.Errorf("%w: a seller receives at least 1 hundredth, not %d", ErrInvalidAmount, amount)
+	}
+	return communityMarketFees.ForSellerReceives(amount), nil
+}
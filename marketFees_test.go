@@ -0,0 +1,78 @@
+package main
+
+import "testing"
+
+func TestMarketFeesForBuyerPays(t *testing.T) {
+	tests := []struct {
+		amount int64
+		want   MarketFees
+	}{
+		{3, MarketFees{BuyerPays: 3, SteamFee: 1, PublisherFee: 1, SellerReceives: 1}},
+		{12, MarketFees{BuyerPays: 12, SteamFee: 1, PublisherFee: 1, SellerReceives: 10}},
+		{100, MarketFees{BuyerPays: 100, SteamFee: 4, PublisherFee: 8, SellerReceives: 88}},
+		{2, MarketFees{BuyerPays: 2, SteamFee: 2}},
+	}
+	for _, test := range tests {
+		if got := communityMarketFees.ForBuyerPays(test.amount); got != test.want {
+			t.Errorf("ForBuyerPays(%d) = %+v, want %+v", test.amount, got, test.want)
+		}
+	}
+	for amount := communityMarketFees.MinimumPrice(); amount <= 100000; amount++ {
+		fees := communityMarketFees.ForBuyerPays(amount)
+		if fees.BuyerPays != amount || fees.SteamFee+fees.PublisherFee+fees.SellerReceives != amount || fees.SellerReceives < 1 {
+			t.Fatalf("ForBuyerPays(%d) = %+v doesn't add up", amount, fees)
+		}
+	}
+}
+
+func TestMarketFeesForSellerReceives(t *testing.T) {
+	tests := []struct {
+		received int64
+		want     MarketFees
+	}{
+		{1, MarketFees{BuyerPays: 3, SteamFee: 1, PublisherFee: 1, SellerReceives: 1}},
+		{88, MarketFees{BuyerPays: 100, SteamFee: 4, PublisherFee: 8, SellerReceives: 88}},
+		{10000, MarketFees{BuyerPays: 11500, SteamFee: 500, PublisherFee: 1000, SellerReceives: 10000}},
+	}
+	for _, test := range tests {
+		if got := communityMarketFees.ForSellerReceives(test.received); got != test.want {
+			t.Errorf("ForSellerReceives(%d) = %+v, want %+v", test.received, got, test.want)
+		}
+	}
+}
+
+func TestMarketFeesRoundTrip(t *testing.T) {
+	schedules := []MarketFeeSchedule{
+		communityMarketFees,
+		{SteamFeePercent: 0.05, SteamFeeMinimum: 1, SteamFeeBase: 0, PublisherFeePercent: 0.15},
+		{SteamFeePercent: 0.05, SteamFeeMinimum: 1, SteamFeeBase: 0},
+	}
+	for _, schedule := range schedules {
+		for received := int64(1); received <= 100000; received++ {
+			price := schedule.ForSellerReceives(received).BuyerPays
+			if got := schedule.ForBuyerPays(price); got.SellerReceives != received {
+				t.Fatalf("%+v: ForBuyerPays(%d) = %+v, want seller receives %d", schedule, price, got, received)
+			}
+		}
+	}
+}
+
+func TestMarketFeesBindingsRejectAmounts(t *testing.T) {
+	a := &App{}
+	for _, amount := range []int64{-100, 0, 1, communityMarketFees.MinimumPrice() - 1} {
+		if _, err := a.MarketFeesForBuyerPays(amount); errorCodeOf(err) != ErrorCodeInvalidInput {
+			t.Errorf("MarketFeesForBuyerPays(%d) = %v, want invalid_input", amount, err)
+		}
+	}
+	for _, amount := range []int64{-1, 0} {
+		if _, err := a.MarketFeesForSellerReceives(amount); errorCodeOf(err) != ErrorCodeInvalidInput {
+			t.Errorf("MarketFeesForSellerReceives(%d) = %v, want invalid_input", amount, err)
+		}
+	}
+	if fees, err := a.MarketFeesForBuyerPays(communityMarketFees.MinimumPrice()); err != nil || fees.SellerReceives != 1 {
+		t.Errorf("MarketFeesForBuyerPays(minimum) = %+v, %v", fees, err)
+	}
+	if fees, err := a.MarketFeesForSellerReceives(1); err != nil || fees.BuyerPays != communityMarketFees.MinimumPrice() {
+		t.Errorf("MarketFeesForSellerReceives(1) = %+v, %v", fees, err)
+	}
+}
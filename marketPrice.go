@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// steamCommunityAppID is the appid community items like backgrounds are listed under
+const steamCommunityAppID = 753
+
+// MarketPrice is the market state of an item. Amounts are in hundredths of Currency, which is
+// how Steam keeps every currency, even the ones it displays without decimals. Whatever Steam
+// didn't report is 0.
+type MarketPrice struct {
+	Currency    int    `json:"currency"`
+	LowestSell  int64  `json:"lowest_sell"`
+	HighestBuy  int64  `json:"highest_buy"`
+	Median      int64  `json:"median"`
+	Volume      int    `json:"volume"`
+	SellOrders  int    `json:"sell_orders"`
+	BuyOrders   int    `json:"buy_orders"`
+	PricePrefix string `json:"price_prefix"`
+	PriceSuffix string `json:"price_suffix"`
+	// Formatted is the lowest sell price the way Steam displays it
+	Formatted string `json:"formatted"`
+	// LowestSellFees splits the lowest sell price, what listing the item at it would net
+	LowestSellFees *MarketFees `json:"lowest_sell_fees"`
+	// HighestBuyFees splits the highest buy order, what selling to it right away nets
+	HighestBuyFees *MarketFees `json:"highest_buy_fees"`
+	// FormattedReceives is what the seller receives of the lowest sell price, formatted like Formatted
+	FormattedReceives string `json:"formatted_receives"`
+}
+
+// applyFees splits the lowest sell and highest buy price by schedule, prices Steam didn't report stay nil
+func (p *MarketPrice) applyFees(schedule MarketFeeSchedule) {
+	p.LowestSellFees, p.HighestBuyFees, p.FormattedReceives = nil, nil, ""
+	if p.LowestSell > 0 {
+		fees := schedule.ForBuyerPays(p.LowestSell)
+		p.LowestSellFees = &fees
+		if currency, ok := currencyByID(p.Currency); ok {
+			p.FormattedReceives = currency.Format(fees.SellerReceives)
+		}
+	}
+	if p.HighestBuy > 0 {
+		fees := schedule.ForBuyerPays(p.HighestBuy)
+		p.HighestBuyFees = &fees
+	}
+}
+
+type orderHistogramResponse struct {
+	Success          int                 `json:"success"`
+	HighestBuyOrder  json.Number         `json:"highest_buy_order"`
+	LowestSellOrder  json.Number         `json:"lowest_sell_order"`
+	SellOrderSummary string              `json:"sell_order_summary"`
+	BuyOrderSummary  string              `json:"buy_order_summary"`
+	SellOrderTable   string              `json:"sell_order_table"`
+	BuyOrderTable    string              `json:"buy_order_table"`
+	SellOrderGraph   [][]json.RawMessage `json:"sell_order_graph"`
+	BuyOrderGraph    [][]json.RawMessage `json:"buy_order_graph"`
+	GraphMinX        float64             `json:"graph_min_x"`
+	GraphMaxX        float64             `json:"graph_max_x"`
+	GraphMaxY        float64             `json:"graph_max_y"`
+	PricePrefix      string              `json:"price_prefix"`
+	PriceSuffix      string              `json:"price_suffix"`
+}
+
+type priceOverviewResponse struct {
+	Success     bool   `json:"success"`
+	LowestPrice string `json:"lowest_price"`
+	MedianPrice string `json:"median_price"`
+	Volume      string `json:"volume"`
+}
+
+// orderSummaryPattern captures the highlighted spans of an order summary: the number of orders
+// first and the price after it
+var orderSummaryPattern = regexp.MustCompile(`market_commodity_orders_header_promote">([^<]*)<`)
+
+// parseMarketCount reads a count like "1,234" or "1 234"
+func parseMarketCount(text string) int {
+	count, _ := strconv.Atoi(strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, text))
+	return count
+}
+
+// parseOrderSummary returns the number of orders and the formatted price of a summary like
+// "<span class=...>1234</span> for sale starting at <span class=...>$0.12</span>"
+func parseOrderSummary(summary string) (count int, price string) {
+	matches := orderSummaryPattern.FindAllStringSubmatch(summary, 2)
+	if len(matches) > 0 {
+		count = parseMarketCount(matches[0][1])
+	}
+	if len(matches) > 1 {
+		price = strings.TrimSpace(matches[1][1])
+	}
+	return count, price
+}
+
+func decodeOrderHistogram(body []byte) (orderHistogramResponse, error) {
+	var response orderHistogramResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return orderHistogramResponse{}, fmt.Errorf("failed to parse order histogram: %w: %w", ErrMarkupChanged, err)
+	}
+	if response.Success != 1 {
+		return orderHistogramResponse{}, fmt.Errorf("%w: order histogram answered success %d", ErrMarkupChanged, response.Success)
+	}
+	return response, nil
+}
+
+// parseOrderHistogram reads the market price out of an itemordershistogram response
+func parseOrderHistogram(body []byte, currency int) (MarketPrice, error) {
+	response, err := decodeOrderHistogram(body)
+	if err != nil {
+		return MarketPrice{}, err
+	}
+	return marketPriceFromHistogram(response, currency), nil
+}
+
+func marketPriceFromHistogram(response orderHistogramResponse, currency int) MarketPrice {
+	price := MarketPrice{Currency: currency, PricePrefix: response.PricePrefix, PriceSuffix: response.PriceSuffix}
+	price.LowestSell, _ = response.LowestSellOrder.Int64()
+	price.HighestBuy, _ = response.HighestBuyOrder.Int64()
+	price.SellOrders, price.Formatted = parseOrderSummary(response.SellOrderSummary)
+	price.BuyOrders, _ = parseOrderSummary(response.BuyOrderSummary)
+	return price
+}
+
+// mergePriceOverview adds the median and 24h volume of a priceoverview response to price,
+// reading its prices in the currency of price
+func mergePriceOverview(price *MarketPrice, body []byte) error {
+	currency, ok := currencyByID(price.Currency)
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrInvalidCurrency, price.Currency)
+	}
+	var response priceOverviewResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to parse price overview: %w: %w", ErrMarkupChanged, err)
+	}
+	if !response.Success {
+		return fmt.Errorf("%w: price overview answered without success", ErrMarkupChanged)
+	}
+	if response.MedianPrice != "" {
+		median, err := currency.Parse(response.MedianPrice)
+		if err != nil {
+			return err
+		}
+		price.Median = median
+	}
+	price.Volume = parseMarketCount(response.Volume)
+	if price.LowestSell == 0 && response.LowestPrice != "" {
+		lowest, err := currency.Parse(response.LowestPrice)
+		if err != nil {
+			return err
+		}
+		price.LowestSell = lowest
+	}
+	if price.Formatted == "" {
+		price.Formatted = response.LowestPrice
+	}
+	return nil
+}
+
+func fetchOrderHistogram(ctx context.Context, nameID int, currency int, language string) (orderHistogramResponse, error) {
+	body, err := steamClient.GetBody(ctx, fmt.Sprintf("https://steamcommunity.com/market/itemordershistogram?language=%s&currency=%d&item_nameid=%d", language, currency, nameID))
+	if err != nil {
+		return orderHistogramResponse{}, err
+	}
+	return decodeOrderHistogram(body)
+}
+
+func getPriceOverview(ctx context.Context, price *MarketPrice, appid int, marketHashName string) error {
+	query := url.Values{}
+	query.Set("appid", strconv.Itoa(appid))
+	query.Set("currency", strconv.Itoa(price.Currency))
+	query.Set("market_hash_name", marketHashName)
+	body, err := steamClient.GetBody(ctx, "https://steamcommunity.com/market/priceoverview/?"+query.Encode())
+	if err != nil {
+		return err
+	}
+	return mergePriceOverview(price, body)
+}
+
+// getMarketPrice combines the order histogram of nameID with the price overview of the
+// listing, which every price fetches for its median and volume. Steam limits the overview much
+// harder, so it has its own bucket in defaultHostRateLimits instead of taking from the market's,
+// and a failed overview only leaves Median and Volume at 0. The order book of the histogram is
+// kept in memory for GetOrderBook.
+func getMarketPrice(ctx context.Context, nameID int, marketHashName string, currency int, language string, cache *Cache) (MarketPrice, error) {
+	response, err := fetchOrderHistogram(ctx, nameID, currency, language)
+	if err != nil {
+		return MarketPrice{}, err
+	}
+	cache.orderBooks.Set(orderBookCacheKey(nameID, currency, language), orderBookFromHistogram(response, nameID, currency), orderBookTTL)
+	price := marketPriceFromHistogram(response, currency)
+	err = getPriceOverview(ctx, &price, steamCommunityAppID, marketHashName)
+	if err != nil {
+		if ctx.Err() != nil {
+			return MarketPrice{}, ctx.Err()
+		}
+		fmt.Printf("Error getting price overview of %s: %v", marketHashName, err)
+	}
+	return price, nil
+}
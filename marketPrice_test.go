@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseMarketPrice(t *testing.T) {
+	histogram, err := os.ReadFile(filepath.Join("testdata", "market", "itemordershistogram.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	overview, err := os.ReadFile(filepath.Join("testdata", "market", "priceoverview.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	price, err := parseOrderHistogram(histogram, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mergePriceOverview(&price, overview); err != nil {
+		t.Fatal(err)
+	}
+	price.applyFees(communityMarketFees)
+	checkGolden(t, "market", "market_price", price)
+}
+
+func TestParseOrderHistogramFailure(t *testing.T) {
+	if _, err := parseOrderHistogram([]byte(`{"success":16}`), 1); err == nil {
+		t.Error("expected an error for an unsuccessful histogram")
+	}
+}
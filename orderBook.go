@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// orderBookTTL matches the disk cache TTL of market prices, both come from the same histogram
+const orderBookTTL = 10 * time.Minute
+
+// OrderBookLevel is one price of an order book. Cumulative counts the orders at this price and
+// every better one, lower prices for sell orders and higher prices for buy orders.
+type OrderBookLevel struct {
+	Price      int64 `json:"price"`
+	Quantity   int   `json:"quantity"`
+	Cumulative int   `json:"cumulative"`
+	// Aggregate is set on a last table row that sums every price beyond it ("$0.20 or more")
+	Aggregate bool `json:"aggregate"`
+}
+
+// OrderBook is the full itemordershistogram of an item, prices in hundredths like MarketPrice.
+// The graphs cover every price Steam plots, the tables only the best few with the rest summed up.
+type OrderBook struct {
+	NameID      int              `json:"name_id"`
+	Currency    int              `json:"currency"`
+	HighestBuy  int64            `json:"highest_buy"`
+	LowestSell  int64            `json:"lowest_sell"`
+	BuyGraph    []OrderBookLevel `json:"buy_graph"`
+	SellGraph   []OrderBookLevel `json:"sell_graph"`
+	BuyTable    []OrderBookLevel `json:"buy_table"`
+	SellTable   []OrderBookLevel `json:"sell_table"`
+	GraphMinX   int64            `json:"graph_min_x"`
+	GraphMaxX   int64            `json:"graph_max_x"`
+	GraphMaxY   int              `json:"graph_max_y"`
+	PricePrefix string           `json:"price_prefix"`
+	PriceSuffix string           `json:"price_suffix"`
+}
+
+// SellDepth returns how many items are listed at price or less, how many can be bought under it
+func (b OrderBook) SellDepth(price int64) int {
+	i := sort.Search(len(b.SellGraph), func(i int) bool { return b.SellGraph[i].Price > price })
+	if i == 0 {
+		return 0
+	}
+	return b.SellGraph[i-1].Cumulative
+}
+
+// BuyDepth returns how many buy orders pay price or more, how many can be sold at it right away
+func (b OrderBook) BuyDepth(price int64) int {
+	i := sort.Search(len(b.BuyGraph), func(i int) bool { return b.BuyGraph[i].Price < price })
+	if i == 0 {
+		return 0
+	}
+	return b.BuyGraph[i-1].Cumulative
+}
+
+// majorToMinor converts a price the graphs carry in major units, like 0.12, to hundredths
+func majorToMinor(price float64) int64 {
+	return int64(math.Round(price * 100))
+}
+
+// parseOrderGraph reads a graph of [price, cumulative quantity, label] points
+func parseOrderGraph(points [][]json.RawMessage) []OrderBookLevel {
+	levels := []OrderBookLevel{}
+	previous := 0
+	for _, point := range points {
+		if len(point) < 2 {
+			continue
+		}
+		var price float64
+		var cumulative int
+		if json.Unmarshal(point[0], &price) != nil || json.Unmarshal(point[1], &cumulative) != nil {
+			continue
+		}
+		levels = append(levels, OrderBookLevel{
+			Price:      majorToMinor(price),
+			Quantity:   cumulative - previous,
+			Cumulative: cumulative,
+		})
+		previous = cumulative
+	}
+	return levels
+}
+
+// parseOrderTable reads the rows of a market_commodity_orders_table priced in currency,
+// markAggregateRow tells whether the last one sums up the rest
+func parseOrderTable(table string, currency SteamCurrency) []OrderBookLevel {
+	levels := []OrderBookLevel{}
+	doc, err := html.Parse(strings.NewReader(table))
+	if err != nil {
+		return levels
+	}
+	cumulative := 0
+	for _, row := range findNodes(doc, func(n *html.Node) bool { return isElement(n, "tr") }) {
+		cells := findNodes(row, func(n *html.Node) bool { return isElement(n, "td") })
+		if len(cells) < 2 {
+			continue
+		}
+		price, err := currency.ParseWithin(nodeText(cells[0]))
+		if err != nil {
+			continue
+		}
+		quantity := parseMarketCount(nodeText(cells[1]))
+		cumulative += quantity
+		levels = append(levels, OrderBookLevel{
+			Price:      price,
+			Quantity:   quantity,
+			Cumulative: cumulative,
+		})
+	}
+	return levels
+}
+
+// markAggregateRow flags the last row of table when it counts more orders than depth, the graph,
+// has up to its price. Only the row Steam sums every further price into does, and unlike its
+// "or more" label that holds in every language.
+func markAggregateRow(table []OrderBookLevel, depth func(int64) int) {
+	if len(table) == 0 {
+		return
+	}
+	last := &table[len(table)-1]
+	last.Aggregate = last.Cumulative > depth(last.Price)
+}
+
+func orderBookFromHistogram(response orderHistogramResponse, nameID int, currency int) OrderBook {
+	tableCurrency, _ := currencyByID(currency)
+	book := OrderBook{
+		NameID:      nameID,
+		Currency:    currency,
+		BuyGraph:    parseOrderGraph(response.BuyOrderGraph),
+		SellGraph:   parseOrderGraph(response.SellOrderGraph),
+		BuyTable:    parseOrderTable(response.BuyOrderTable, tableCurrency),
+		SellTable:   parseOrderTable(response.SellOrderTable, tableCurrency),
+		GraphMinX:   majorToMinor(response.GraphMinX),
+		GraphMaxX:   majorToMinor(response.GraphMaxX),
+		GraphMaxY:   int(response.GraphMaxY),
+		PricePrefix: response.PricePrefix,
+		PriceSuffix: response.PriceSuffix,
+	}
+	book.HighestBuy, _ = response.HighestBuyOrder.Int64()
+	book.LowestSell, _ = response.LowestSellOrder.Int64()
+	markAggregateRow(book.BuyTable, book.BuyDepth)
+	markAggregateRow(book.SellTable, book.SellDepth)
+	return book
+}
+
+// parseOrderBook reads an itemordershistogram response into an order book
+func parseOrderBook(body []byte, nameID int, currency int) (OrderBook, error) {
+	response, err := decodeOrderHistogram(body)
+	if err != nil {
+		return OrderBook{}, err
+	}
+	return orderBookFromHistogram(response, nameID, currency), nil
+}
+
+func orderBookCacheKey(nameID int, currency int, language string) string {
+	return strconv.Itoa(nameID) + ":" + strconv.Itoa(currency) + ":" + language
+}
+
+// getOrderBook returns the order book of the item with item_nameid nameID, reusing the one the
+// last price lookup of the item fetched
+func getOrderBook(ctx context.Context, nameID int, currency int, language string, cache *Cache) (OrderBook, error) {
+	if book, status := cache.orderBooks.Get(orderBookCacheKey(nameID, currency, language)); status == CacheHit {
+		return book, nil
+	}
+	response, err := fetchOrderHistogram(ctx, nameID, currency, language)
+	if err != nil {
+		return OrderBook{}, err
+	}
+	book := orderBookFromHistogram(response, nameID, currency)
+	cache.orderBooks.Set(orderBookCacheKey(nameID, currency, language), book, orderBookTTL)
+	return book, nil
+}
+
+// GetOrderBook returns the buy and sell orders of the item with item_nameid nameID, the
+// item_market_id of an equipped item
+func (a *App) GetOrderBook(inspectionID string, nameID int, currency int) (_ OrderBook, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return OrderBook{}, err
+	}
+	if nameID <= 0 {
+		return OrderBook{}, fmt.Errorf("%w: item has no market listing", ErrNotMarketable)
+	}
+	if err := validateCurrency(currency); err != nil {
+		return OrderBook{}, err
+	}
+	return getOrderBook(ctx, nameID, currency, a.languageFor(""), a.cache)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseOrderBook(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "market", "itemordershistogram.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	book, err := parseOrderBook(body, 175880240, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "market", "order_book", book)
+
+	depths := []struct {
+		name  string
+		depth func(int64) int
+		price int64
+		want  int
+	}{
+		{"sell under the lowest ask", book.SellDepth, 11, 0},
+		{"sell at the lowest ask", book.SellDepth, 12, 41},
+		{"sell between levels", book.SellDepth, 20, 188},
+		{"buy above the highest bid", book.BuyDepth, 11, 0},
+		{"buy at the highest bid", book.BuyDepth, 10, 220},
+		{"buy below every bid", book.BuyDepth, 1, 1311},
+	}
+	for _, test := range depths {
+		if got := test.depth(test.price); got != test.want {
+			t.Errorf("%s: depth at %d = %d, want %d", test.name, test.price, got, test.want)
+		}
+	}
+}
+
+func TestMarkAggregateRow(t *testing.T) {
+	book := OrderBook{SellGraph: parseOrderGraph([][]json.RawMessage{
+		{json.RawMessage(`0.12`), json.RawMessage(`41`)},
+		{json.RawMessage(`0.13`), json.RawMessage(`188`)},
+	})}
+	eur, _ := currencyByID(3)
+	complete := parseOrderTable(`<table><tr><th>Preis</th><th>Menge</th></tr><tr><td>0,12€</td><td>41</td></tr><tr><td>0,13€</td><td>147</td></tr></table>`, eur)
+	markAggregateRow(complete, book.SellDepth)
+	if complete[len(complete)-1].Aggregate {
+		t.Error("last row of a complete table marked as aggregate")
+	}
+	summed := parseOrderTable(`<table><tr><th>Preis</th><th>Menge</th></tr><tr><td>0,12€</td><td>41</td></tr><tr><td>0,13€ oder mehr</td><td>300</td></tr></table>`, eur)
+	markAggregateRow(summed, book.SellDepth)
+	if !summed[len(summed)-1].Aggregate || summed[0].Aggregate {
+		t.Errorf("aggregate rows = %+v, want only the last one", summed)
+	}
+}
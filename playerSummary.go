@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// playerSummariesBatchSize is the most steamids GetPlayerSummaries accepts in one call
+const playerSummariesBatchSize = 100
+
+// PersonaState mirrors Steam's EPersonaState
+type PersonaState int
+
+const (
+	PersonaStateOffline PersonaState = iota
+	PersonaStateOnline
+	PersonaStateBusy
+	PersonaStateAway
+	PersonaStateSnooze
+	PersonaStateLookingToTrade
+	PersonaStateLookingToPlay
+)
+
+var personaStateNames = map[PersonaState]string{
+	PersonaStateOffline:        "offline",
+	PersonaStateOnline:         "online",
+	PersonaStateBusy:           "busy",
+	PersonaStateAway:           "away",
+	PersonaStateSnooze:         "snooze",
+	PersonaStateLookingToTrade: "looking_to_trade",
+	PersonaStateLookingToPlay:  "looking_to_play",
+}
+
+func (s PersonaState) String() string {
+	if name, ok := personaStateNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ProfileStatus tells whether an inspection can see anything on the profile
+type ProfileStatus string
+
+const (
+	ProfileStatusPublic      ProfileStatus = "public"
+	ProfileStatusFriendsOnly ProfileStatus = "friends_only"
+	ProfileStatusPrivate     ProfileStatus = "private"
+	ProfileStatusNotSetUp    ProfileStatus = "not_set_up"
+)
+
+// communityVisibilityPublic is the communityvisibilitystate of public profiles, every other
+// value means the profile is hidden from the key owner
+const communityVisibilityPublic = 3
+
+type PlayerSummary struct {
+	SteamID          SteamID       `json:"steam_id" ts_type:"string"`
+	PersonaName      string        `json:"persona_name"`
+	PersonaState     PersonaState  `json:"persona_state"`
+	PersonaStateName string        `json:"persona_state_name"`
+	Visibility       int           `json:"visibility"`
+	ProfileSetUp     bool          `json:"profile_set_up"`
+	Status           ProfileStatus `json:"status"`
+	ProfileURL       string        `json:"profile_url"`
+	AvatarHash       string        `json:"avatar_hash"`
+	Avatar           AvatarURLs    `json:"avatar"`
+	TimeCreated      int64         `json:"time_created"`
+	LastLogoff       int64         `json:"last_logoff"`
+	CountryCode      string        `json:"country_code"`
+	GameID           string        `json:"game_id"`
+	GameName         string        `json:"game_name"`
+}
+
+type playerSummariesResponse struct {
+	Response struct {
+		Players []struct {
+			SteamID                  SteamID `json:"steamid"`
+			CommunityVisibilityState int     `json:"communityvisibilitystate"`
+			ProfileState             int     `json:"profilestate"`
+			PersonaName              string  `json:"personaname"`
+			ProfileURL               string  `json:"profileurl"`
+			Avatar                   string  `json:"avatar"`
+			AvatarMedium             string  `json:"avatarmedium"`
+			AvatarFull               string  `json:"avatarfull"`
+			AvatarHash               string  `json:"avatarhash"`
+			LastLogoff               int64   `json:"lastlogoff"`
+			PersonaState             int     `json:"personastate"`
+			TimeCreated              int64   `json:"timecreated"`
+			LocCountryCode           string  `json:"loccountrycode"`
+			GameID                   string  `json:"gameid"`
+			GameExtraInfo            string  `json:"gameextrainfo"`
+		} `json:"players"`
+	} `json:"response"`
+}
+
+func profileStatus(visibility int, setUp bool) ProfileStatus {
+	switch {
+	case !setUp:
+		return ProfileStatusNotSetUp
+	case visibility != communityVisibilityPublic:
+		return ProfileStatusPrivate
+	}
+	return ProfileStatusPublic
+}
+
+func getPlayerSummariesViaAPI(ctx context.Context, steamIDs []SteamID, key string) ([]PlayerSummary, error) {
+	ids := make([]string, len(steamIDs))
+	for i, steamID := range steamIDs {
+		ids[i] = steamID.Steam64()
+	}
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("steamids", strings.Join(ids, ","))
+	body, err := steamClient.GetBody(ctx, "https://api.steampowered.com/ISteamUser/GetPlayerSummaries/v2/?"+query.Encode())
+	if err != nil {
+		return nil, webAPIError(err)
+	}
+	var response playerSummariesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse player summaries: %w: %w", ErrMarkupChanged, err)
+	}
+	summaries := make([]PlayerSummary, 0, len(response.Response.Players))
+	for _, player := range response.Response.Players {
+		setUp := player.ProfileState == 1
+		summaries = append(summaries, PlayerSummary{
+			SteamID:          player.SteamID,
+			PersonaName:      player.PersonaName,
+			PersonaState:     PersonaState(player.PersonaState),
+			PersonaStateName: PersonaState(player.PersonaState).String(),
+			Visibility:       player.CommunityVisibilityState,
+			ProfileSetUp:     setUp,
+			Status:           profileStatus(player.CommunityVisibilityState, setUp),
+			ProfileURL:       player.ProfileURL,
+			AvatarHash:       player.AvatarHash,
+			Avatar:           AvatarURLs{Full: player.AvatarFull, Medium: player.AvatarMedium, Small: player.Avatar},
+			TimeCreated:      player.TimeCreated,
+			LastLogoff:       player.LastLogoff,
+			CountryCode:      player.LocCountryCode,
+			GameID:           player.GameID,
+			GameName:         player.GameExtraInfo,
+		})
+	}
+	return summaries, nil
+}
+
+// getPlayerSummaryViaXML builds a summary from the community XML when there is no key.
+// It knows less than the API: no country, no logoff time and no exact persona state.
+func getPlayerSummaryViaXML(ctx context.Context, steamID SteamID) (PlayerSummary, error) {
+	profile, err := getCommunityProfileXML(ctx, steamID.ProfileURL())
+	if err != nil {
+		return PlayerSummary{}, err
+	}
+	summary := PlayerSummary{
+		SteamID:      steamID,
+		PersonaName:  profile.PersonaName,
+		Visibility:   profile.VisibilityState,
+		ProfileSetUp: !strings.Contains(profile.PrivacyMessage, "not yet set up"),
+		ProfileURL:   steamID.ProfileURL(),
+		Avatar:       AvatarURLs{Full: profile.AvatarFull, Medium: profile.AvatarMedium, Small: profile.AvatarIcon},
+		GameName:     profile.InGameName,
+	}
+	if !profile.MemberSinceTime.IsZero() {
+		summary.TimeCreated = profile.MemberSinceTime.Unix()
+	}
+	if matches := avatarHashPattern.FindStringSubmatch(profile.AvatarFull); len(matches) > 1 {
+		summary.AvatarHash = matches[1]
+	}
+	if profile.OnlineState != "offline" {
+		summary.PersonaState = PersonaStateOnline
+	}
+	summary.PersonaStateName = summary.PersonaState.String()
+	summary.Status = profileStatus(profile.VisibilityState, summary.ProfileSetUp)
+	if summary.Status == ProfileStatusPrivate && profile.PrivacyState == "friendsonly" {
+		summary.Status = ProfileStatusFriendsOnly
+	}
+	return summary, nil
+}
+
+// GetPlayerSummaries returns the summaries of steamIDs in their order, batching API calls by
+// 100 ids. Without a key, or with a rejected one, every profile is read from its XML instead.
+func GetPlayerSummaries(ctx context.Context, steamIDs []SteamID, key string) ([]PlayerSummary, error) {
+	if key != "" {
+		byID := make(map[SteamID]PlayerSummary, len(steamIDs))
+		var err error
+		for start := 0; start < len(steamIDs) && err == nil; start += playerSummariesBatchSize {
+			end := start + playerSummariesBatchSize
+			if end > len(steamIDs) {
+				end = len(steamIDs)
+			}
+			var summaries []PlayerSummary
+			summaries, err = getPlayerSummariesViaAPI(ctx, steamIDs[start:end], key)
+			for _, summary := range summaries {
+				byID[summary.SteamID] = summary
+			}
+		}
+		if err == nil {
+			summaries := make([]PlayerSummary, 0, len(steamIDs))
+			for _, steamID := range steamIDs {
+				if summary, ok := byID[steamID]; ok {
+					summaries = append(summaries, summary)
+				}
+			}
+			return summaries, nil
+		}
+		if !errors.Is(err, ErrInvalidAPIKey) {
+			return nil, err
+		}
+	}
+	summaries := make([]PlayerSummary, 0, len(steamIDs))
+	for _, steamID := range steamIDs {
+		summary, err := getPlayerSummaryViaXML(ctx, steamID)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// GetPlayerSummary returns the summaries of the given SteamIDs, accounts that don't exist are
+// left out
+func (a *App) GetPlayerSummary(inspectionID string, steamIDs []string) (_ []PlayerSummary, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]SteamID, 0, len(steamIDs))
+	for _, input := range steamIDs {
+		steamID, err := ParseSteamID(input)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidSteamID, input, err)
+		}
+		ids = append(ids, steamID)
+	}
+	return GetPlayerSummaries(ctx, ids, a.currentSettings().APIKey)
+}
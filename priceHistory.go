@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// priceHistoryTTL is how long a parsed history is reused, Steam adds one point per hour
+const priceHistoryTTL = time.Hour
+
+// priceHistoryWindows are the day ranges PriceHistory summarizes
+var priceHistoryWindows = []int{7, 30, 90}
+
+// PriceHistoryPoint is one point of the market price history, hourly for the last month and
+// daily before that. Median is in hundredths like MarketPrice.
+type PriceHistoryPoint struct {
+	Timestamp int64 `json:"timestamp"`
+	Median    int64 `json:"median"`
+	Volume    int   `json:"volume"`
+}
+
+// PriceHistoryWindow summarizes the last Days days of a history. Average is weighted by volume
+// and Change is the percent change from the first to the last median of the window.
+type PriceHistoryWindow struct {
+	Days    int     `json:"days"`
+	Average int64   `json:"average"`
+	Change  float64 `json:"change"`
+	Volume  int     `json:"volume"`
+}
+
+// PriceHistory is the history a listing page plots. Currency is the one its medians are in, 0
+// when the page doesn't tell.
+type PriceHistory struct {
+	MarketHashName string               `json:"market_hash_name"`
+	Currency       int                  `json:"currency"`
+	PricePrefix    string               `json:"price_prefix"`
+	PriceSuffix    string               `json:"price_suffix"`
+	Points         []PriceHistoryPoint  `json:"points"`
+	Windows        []PriceHistoryWindow `json:"windows"`
+}
+
+var (
+	priceHistoryLinePattern   = regexp.MustCompile(`var line1=(\[.*?\]);`)
+	priceHistoryPrefixPattern = regexp.MustCompile(`var strFormatPrefix = "((?:[^"\\]|\\.)*)";`)
+	priceHistorySuffixPattern = regexp.MustCompile(`var strFormatSuffix = "((?:[^"\\]|\\.)*)";`)
+	priceHistoryWalletPattern = regexp.MustCompile(`"wallet_currency":\s*(\d+)`)
+)
+
+// priceHistoryCurrency tells the currency a listing page plots its history in: the wallet
+// currency when the page has one, otherwise the only currency whose symbol matches the format
+// prefix and suffix. Shared symbols like "kr" and "¥" leave it unknown.
+func priceHistoryCurrency(body []byte, prefix string, suffix string) int {
+	if m := priceHistoryWalletPattern.FindSubmatch(body); m != nil {
+		if id, err := strconv.Atoi(string(m[1])); err == nil && validateCurrency(id) == nil {
+			return id
+		}
+	}
+	prefix, suffix = strings.TrimSpace(prefix), strings.TrimSpace(suffix)
+	found := 0
+	for _, currency := range steamCurrencies {
+		symbol, other := prefix, suffix
+		if currency.Placement == SymbolSuffix {
+			symbol, other = suffix, prefix
+		}
+		if symbol != currency.Symbol || other != "" {
+			continue
+		}
+		if found != 0 {
+			return 0
+		}
+		found = currency.ID
+	}
+	return found
+}
+
+// parsePriceHistoryTime reads dates like "Mar 10 2015 01: +0", the hour of a UTC timestamp
+func parsePriceHistoryTime(value string) (time.Time, error) {
+	value, _, _ = strings.Cut(value, ":")
+	return time.Parse("Jan 02 2006 15", value)
+}
+
+// parsePriceHistory extracts the price history a market listing page plots from its line1 variable
+func parsePriceHistory(body []byte, now time.Time) (PriceHistory, error) {
+	history := PriceHistory{Points: []PriceHistoryPoint{}, Windows: []PriceHistoryWindow{}}
+	matches := priceHistoryLinePattern.FindSubmatch(body)
+	if matches == nil {
+		return PriceHistory{}, fmt.Errorf("%w: no price history on the listing page", ErrMarkupChanged)
+	}
+	var rows [][]json.RawMessage
+	if err := json.Unmarshal(matches[1], &rows); err != nil {
+		return PriceHistory{}, fmt.Errorf("failed to parse price history: %w: %w", ErrMarkupChanged, err)
+	}
+	for _, row := range rows {
+		if len(row) < 3 {
+			continue
+		}
+		var date, volume string
+		var median float64
+		if json.Unmarshal(row[0], &date) != nil || json.Unmarshal(row[1], &median) != nil || json.Unmarshal(row[2], &volume) != nil {
+			continue
+		}
+		t, err := parsePriceHistoryTime(date)
+		if err != nil {
+			continue
+		}
+		history.Points = append(history.Points, PriceHistoryPoint{
+			Timestamp: t.Unix(),
+			Median:    int64(math.Round(median * 100)),
+			Volume:    parseMarketCount(volume),
+		})
+	}
+	if m := priceHistoryPrefixPattern.FindSubmatch(body); m != nil {
+		history.PricePrefix, _ = strconv.Unquote(`"` + string(m[1]) + `"`)
+	}
+	if m := priceHistorySuffixPattern.FindSubmatch(body); m != nil {
+		history.PriceSuffix, _ = strconv.Unquote(`"` + string(m[1]) + `"`)
+	}
+	history.Currency = priceHistoryCurrency(body, history.PricePrefix, history.PriceSuffix)
+	for _, days := range priceHistoryWindows {
+		history.Windows = append(history.Windows, summarizePriceHistory(history.Points, days, now))
+	}
+	return history, nil
+}
+
+// summarizePriceHistory computes the window of the last days days before now
+func summarizePriceHistory(points []PriceHistoryPoint, days int, now time.Time) PriceHistoryWindow {
+	window := PriceHistoryWindow{Days: days}
+	since := now.AddDate(0, 0, -days).Unix()
+	var first, last *PriceHistoryPoint
+	var weighted, total int64
+	var count int
+	for i := range points {
+		point := &points[i]
+		if point.Timestamp < since || point.Timestamp > now.Unix() {
+			continue
+		}
+		if first == nil {
+			first = point
+		}
+		last = point
+		weighted += point.Median * int64(point.Volume)
+		total += point.Median
+		count++
+		window.Volume += point.Volume
+	}
+	if count == 0 {
+		return window
+	}
+	if window.Volume > 0 {
+		window.Average = int64(math.Round(float64(weighted) / float64(window.Volume)))
+	} else {
+		window.Average = int64(math.Round(float64(total) / float64(count)))
+	}
+	if first.Median > 0 {
+		window.Change = math.Round(float64(last.Median-first.Median)/float64(first.Median)*10000) / 100
+	}
+	return window
+}
+
+// getPriceHistory returns the price history of a community market listing, reusing the one
+// putMarketURIToEquippedItem parsed while looking the item up
+func getPriceHistory(ctx context.Context, marketHashName string, cache *Cache) (PriceHistory, error) {
+	if history, status := cache.priceHistories.Get(marketHashName); status == CacheHit {
+		return history, nil
+	}
+	body, err := steamClient.GetBody(ctx, marketListingURL(marketHashName))
+	if err != nil {
+		return PriceHistory{}, err
+	}
+	if marketListingNotFound(body) {
+		return PriceHistory{}, fmt.Errorf("%w: %s", ErrNotMarketable, marketHashName)
+	}
+	return cachePriceHistory(marketHashName, body, cache)
+}
+
+// cachePriceHistory parses the price history of a downloaded listing page and remembers it
+func cachePriceHistory(marketHashName string, body []byte, cache *Cache) (PriceHistory, error) {
+	history, err := parsePriceHistory(body, time.Now())
+	if err != nil {
+		return PriceHistory{}, err
+	}
+	history.MarketHashName = marketHashName
+	cache.priceHistories.Set(marketHashName, history, priceHistoryTTL)
+	return history, nil
+}
+
+// GetPriceHistory returns the price history of the community market listing named marketHashName,
+// "<appid>-<item name>" for profile items
+func (a *App) GetPriceHistory(inspectionID string, marketHashName string) (_ PriceHistory, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return PriceHistory{}, err
+	}
+	return getPriceHistory(ctx, marketHashName, a.cache)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParsePriceHistory(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "market", "listing.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	history, err := parsePriceHistory(body, time.Date(2023, time.September, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "market", "price_history", history)
+}
+
+func TestParsePriceHistoryMissing(t *testing.T) {
+	if _, err := parsePriceHistory([]byte("<html></html>"), time.Now()); err == nil {
+		t.Error("expected an error for a page without line1")
+	}
+}
+
+func TestPriceHistoryCurrency(t *testing.T) {
+	tests := []struct {
+		body   string
+		prefix string
+		suffix string
+		want   int
+	}{
+		{``, "$", "", 1},
+		{``, "", "€", 3},
+		{``, "CDN$ ", "", 20},
+		{``, "", " kr", 0},
+		{``, "¥ ", "", 0},
+		{``, "", "", 0},
+		{`var g_rgWalletInfo = {"wallet_currency":9,"wallet_country":"NO"};`, "", " kr", 9},
+	}
+	for _, test := range tests {
+		if got := priceHistoryCurrency([]byte(test.body), test.prefix, test.suffix); got != test.want {
+			t.Errorf("priceHistoryCurrency(%q, %q, %q) = %d, want %d", test.body, test.prefix, test.suffix, got, test.want)
+		}
+	}
+}
+
+func TestMarketListingURL(t *testing.T) {
+	got := marketListingURL("753-Cozy Cottage #2/100%?")
+	if want := "https://steamcommunity.com/market/listings/753/753-Cozy%20Cottage%20%232%2F100%25%3F"; got != want {
+		t.Errorf("marketListingURL = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// BackgroundMedia is every rendition Steam offers of one background. Static backgrounds only
+// have Image, animated ones come as videos with a Poster frame.
+type BackgroundMedia struct {
+	Image  string `json:"image"`
+	Mp4    string `json:"mp4"`
+	Webm   string `json:"webm"`
+	Poster string `json:"poster"`
+}
+
+type ProfileBackground struct {
+	Profile     BackgroundMedia `json:"profile"`
+	MiniProfile BackgroundMedia `json:"mini_profile"`
+}
+
+var (
+	backgroundImageStylePattern = regexp.MustCompile(`background-image:\s*url\(\s*['"]?([^'")]+?)['"]?\s*\)`)
+	profileDataSteamIDPattern   = regexp.MustCompile(`g_rgProfileData\s*=\s*\{.*?"steamid":"(\d+)"`)
+)
+
+// videoMedia reads the sources and poster of a <video>, sources are told apart by their type
+// attribute and fall back to the file extension
+func videoMedia(video *html.Node) BackgroundMedia {
+	media := BackgroundMedia{Poster: htmlAttr(video, "poster")}
+	for _, source := range findNodes(video, func(n *html.Node) bool { return isElement(n, "source") }) {
+		src := htmlAttr(source, "src")
+		mediaType := htmlAttr(source, "type")
+		switch {
+		case mediaType == "video/mp4" || (mediaType == "" && strings.HasSuffix(src, ".mp4")):
+			media.Mp4 = src
+		case mediaType == "video/webm" || (mediaType == "" && strings.HasSuffix(src, ".webm")):
+			media.Webm = src
+		}
+	}
+	return media
+}
+
+// extractProfileBackground finds the background of a profile page, doc being the whole page
+func extractProfileBackground(doc *html.Node) BackgroundMedia {
+	page := findNode(doc, func(n *html.Node) bool {
+		// <body> carries the same classes but never the background itself
+		return isElement(n, "div") && hasClass(n, "profile_page") && hasClass(n, "has_profile_background")
+	})
+	if page == nil {
+		return BackgroundMedia{}
+	}
+	var media BackgroundMedia
+	if animated := findNode(page, func(n *html.Node) bool { return hasClass(n, "profile_animated_background") }); animated != nil {
+		if video := findNode(animated, func(n *html.Node) bool { return isElement(n, "video") }); video != nil {
+			media = videoMedia(video)
+		}
+	}
+	if matches := backgroundImageStylePattern.FindStringSubmatch(htmlAttr(page, "style")); len(matches) > 1 {
+		media.Image = strings.TrimSpace(matches[1])
+	}
+	return media
+}
+
+// extractMiniProfileBackground finds the nameplate of a /miniprofile/ page
+func extractMiniProfileBackground(doc *html.Node) BackgroundMedia {
+	nameplate := findNode(doc, func(n *html.Node) bool { return hasClass(n, "miniprofile_nameplate") })
+	if nameplate == nil {
+		return BackgroundMedia{}
+	}
+	if isElement(nameplate, "img") {
+		return BackgroundMedia{Image: htmlAttr(nameplate, "src")}
+	}
+	return videoMedia(nameplate)
+}
+
+// extractProfileSteamID reads the SteamID of the profile owner from the page's g_rgProfileData
+func extractProfileSteamID(body []byte) (SteamID, bool) {
+	matches := profileDataSteamIDPattern.FindSubmatch(body)
+	if len(matches) < 2 {
+		return 0, false
+	}
+	steamID, err := ParseSteamID(string(matches[1]))
+	if err != nil {
+		return 0, false
+	}
+	return steamID, true
+}
+
+func getMiniProfileBackground(ctx context.Context, steamID SteamID) (BackgroundMedia, error) {
+	body, err := steamClient.GetBody(ctx, fmt.Sprintf("https://steamcommunity.com/miniprofile/%d.html", steamID.AccountID()))
+	if err != nil {
+		return BackgroundMedia{}, err
+	}
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return BackgroundMedia{}, fmt.Errorf("failed to parse mini profile: %w: %w", ErrMarkupChanged, err)
+	}
+	return extractMiniProfileBackground(doc), nil
+}
+
+// getProfileBackground returns the profile and mini profile backgrounds of the profile at
+// profileURL. A mini profile that fails to load leaves MiniProfile empty and the result uncached.
+func getProfileBackground(ctx context.Context, profileURL string, cache *Cache) (ProfileBackground, error) {
+	if cachedResult, status := cache.backgrounds.Get(profileURL); status == CacheHit {
+		return cachedResult, nil
+	}
+
+	body, err := steamClient.GetBody(ctx, profileURL)
+	if err != nil {
+		return ProfileBackground{}, err
+	}
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return ProfileBackground{}, fmt.Errorf("failed to parse profile page: %w: %w", ErrMarkupChanged, err)
+	}
+	background := ProfileBackground{Profile: extractProfileBackground(doc)}
+
+	complete := true
+	if steamID, ok := extractProfileSteamID(body); ok {
+		background.MiniProfile, err = getMiniProfileBackground(ctx, steamID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ProfileBackground{}, ctx.Err()
+			}
+			complete = false
+		}
+	}
+	if complete {
+		cache.backgrounds.Set(profileURL, background, 7*time.Minute)
+	}
+	return background, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// checkGolden compares got, encoded as indented JSON, with testdata/<dir>/<name>.golden.json
+func checkGolden(t *testing.T, dir string, name string, got any) {
+	t.Helper()
+	data, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, '\n')
+	golden := filepath.Join("testdata", dir, name+".golden.json")
+	if *updateGolden {
+		if err := os.WriteFile(golden, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, want) {
+		t.Errorf("%s differs from %s:\n%s", name, golden, data)
+	}
+}
+
+func parseTestdata(t *testing.T, path string) ([]byte, *html.Node) {
+	t.Helper()
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body, doc
+}
+
+// checkGoldenPages runs a subtest for every page matching testdata/<dir>/<pattern> and compares
+// what parse makes of it with the golden file of the same name
+func checkGoldenPages(t *testing.T, dir string, pattern string, parse func(t *testing.T, body []byte, doc *html.Node) any) {
+	t.Helper()
+	pages, err := filepath.Glob(filepath.Join("testdata", dir, pattern))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) == 0 {
+		t.Fatalf("no pages match testdata/%s/%s", dir, pattern)
+	}
+	for _, page := range pages {
+		name := strings.TrimSuffix(filepath.Base(page), filepath.Ext(page))
+		t.Run(name, func(t *testing.T) {
+			body, doc := parseTestdata(t, page)
+			checkGolden(t, dir, name, parse(t, body, doc))
+		})
+	}
+}
+
+// The profile and miniprofile pages in testdata/backgrounds are hand-reduced to the markup the
+// extractors read, they are not captures of steamcommunity.com. When the markup changes, save
+// the live profile and /miniprofile/<accountid>.html pages over them and rerun with -update.
+
+func TestExtractProfileBackground(t *testing.T) {
+	checkGoldenPages(t, "backgrounds", "profile_*.html", func(t *testing.T, body []byte, doc *html.Node) any {
+		steamID, _ := extractProfileSteamID(body)
+		return struct {
+			SteamID    SteamID         `json:"steam_id"`
+			Background BackgroundMedia `json:"background"`
+		}{steamID, extractProfileBackground(doc)}
+	})
+}
+
+func TestExtractMiniProfileBackground(t *testing.T) {
+	checkGoldenPages(t, "backgrounds", "miniprofile_*.html", func(t *testing.T, body []byte, doc *html.Node) any {
+		return extractMiniProfileBackground(doc)
+	})
+}
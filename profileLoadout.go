@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// equippedSlot is one of the per-slot objects of GetEquippedProfileItems. Empty slots come back as {}.
+type equippedSlot struct {
+	CommunityItemID string             `json:"communityitemid"`
+	ImageSmall      string             `json:"image_small"`
+	ImageLarge      string             `json:"image_large"`
+	Name            string             `json:"name"`
+	ItemTitle       string             `json:"item_title"`
+	ItemDescription string             `json:"item_description"`
+	Appid           int                `json:"appid"`
+	ItemType        int                `json:"item_type"`
+	ItemClass       CommunityItemClass `json:"item_class"`
+	MovieWebm       string             `json:"movie_webm"`
+	MovieMp4        string             `json:"movie_mp4"`
+	MovieWebmSmall  string             `json:"movie_webm_small"`
+	MovieMp4Small   string             `json:"movie_mp4_small"`
+	EquippedFlags   int                `json:"equipped_flags"`
+}
+
+func (s equippedSlot) isEmpty() bool {
+	return s.CommunityItemID == "" && s.Appid == 0 && s.ImageLarge == ""
+}
+
+// ProfileLoadout has one field per equipped slot, nil when nothing is equipped there
+type ProfileLoadout struct {
+	SteamID               SteamID       `json:"steam_id" ts_type:"string"`
+	ProfileBackground     *EquippedItem `json:"profile_background"`
+	MiniProfileBackground *EquippedItem `json:"mini_profile_background"`
+	AvatarFrame           *EquippedItem `json:"avatar_frame"`
+	AnimatedAvatar        *EquippedItem `json:"animated_avatar"`
+	ProfileModifier       *EquippedItem `json:"profile_modifier"`
+	KeyboardSkin          *EquippedItem `json:"keyboard_skin"`
+	StartupMovie          *EquippedItem `json:"startup_movie"`
+}
+
+// Slot returns a pointer to the loadout field holding items of class, nil if the class has no slot
+func (l *ProfileLoadout) Slot(class CommunityItemClass) **EquippedItem {
+	switch class {
+	case CommunityItemClassProfileBackground:
+		return &l.ProfileBackground
+	case CommunityItemClassMiniProfileBackground:
+		return &l.MiniProfileBackground
+	case CommunityItemClassAvatarFrame:
+		return &l.AvatarFrame
+	case CommunityItemClassAnimatedAvatar:
+		return &l.AnimatedAvatar
+	case CommunityItemClassProfileModifier:
+		return &l.ProfileModifier
+	case CommunityItemClassKeyboardSkin:
+		return &l.KeyboardSkin
+	case CommunityItemClassStartupMovie:
+		return &l.StartupMovie
+	}
+	return nil
+}
+
+// Items returns the equipped items in slot order
+func (l *ProfileLoadout) Items() []EquippedItem {
+	var items []EquippedItem
+	for _, item := range []*EquippedItem{l.ProfileBackground, l.MiniProfileBackground, l.AvatarFrame, l.AnimatedAvatar,
+		l.ProfileModifier, l.KeyboardSkin, l.StartupMovie} {
+		if item != nil {
+			items = append(items, *item)
+		}
+	}
+	return items
+}
+
+// buildProfileLoadout fills the slots from the item definitions and falls back to the bare
+// slot objects for items that have no points shop definition. A slot only takes a definition
+// of the same app and item type, the pair the slot object carries in place of a defid.
+func buildProfileLoadout(steamID SteamID, response equippedItemsResponse) ProfileLoadout {
+	loadout := ProfileLoadout{SteamID: steamID}
+	items := equippedItemsFromResponse(response)
+	for _, item := range items {
+		item := item
+		if slot := loadout.Slot(item.CommunityItemClass); slot != nil && *slot == nil {
+			*slot = &item
+		}
+	}
+
+	slots := map[CommunityItemClass]equippedSlot{
+		CommunityItemClassProfileBackground:     response.ProfileBackground,
+		CommunityItemClassMiniProfileBackground: response.MiniProfileBackground,
+		CommunityItemClassAvatarFrame:           response.AvatarFrame,
+		CommunityItemClassAnimatedAvatar:        response.AnimatedAvatar,
+		CommunityItemClassProfileModifier:       response.ProfileModifier,
+		CommunityItemClassKeyboardSkin:          response.KeyboardSkin,
+		CommunityItemClassStartupMovie:          response.StartupMovie,
+	}
+	for class, equipped := range slots {
+		if equipped.isEmpty() {
+			continue
+		}
+		item, ok := findSlotDefinition(items, class, equipped)
+		if ok {
+			item.CommunityItemID = equipped.CommunityItemID
+		} else {
+			item = createEquippedItemFromSlot(class, equipped)
+		}
+		*loadout.Slot(class) = &item
+	}
+	return loadout
+}
+
+// findSlotDefinition returns the definition of class that describes the equipped slot item
+func findSlotDefinition(items []EquippedItem, class CommunityItemClass, equipped equippedSlot) (EquippedItem, bool) {
+	for _, item := range items {
+		if item.CommunityItemClass == class && item.Appid == equipped.Appid && item.CommunityItemType == equipped.ItemType {
+			return item, true
+		}
+	}
+	return EquippedItem{}, false
+}
+
+func createEquippedItemFromSlot(class CommunityItemClass, slot equippedSlot) EquippedItem {
+	itemImageURI := slot.ImageLarge
+	animated := slot.MovieMp4 != "" || slot.MovieWebm != ""
+	if slot.MovieMp4 != "" {
+		itemImageURI = slot.MovieMp4
+	} else if slot.MovieWebm != "" {
+		itemImageURI = slot.MovieWebm
+	}
+	return EquippedItem{
+		Appid:                  slot.Appid,
+		CommunityItemClass:     class,
+		CommunityItemClassName: class.String(),
+		CommunityItemType:      slot.ItemType,
+		CommunityItemID:        slot.CommunityItemID,
+		ItemName:               slot.Name,
+		ItemTitle:              slot.ItemTitle,
+		ItemDescription:        slot.ItemDescription,
+		Animated:               animated,
+		ItemImageURI:           itemImageURI,
+	}
+}
+
+func GetProfileLoadout(ctx context.Context, steamID SteamID, language string) (ProfileLoadout, error) {
+	response, err := getEquippedProfileItems(ctx, steamID.Steam64(), language)
+	if err != nil {
+		return ProfileLoadout{}, err
+	}
+	return buildProfileLoadout(steamID, response), nil
+}
+
+func (a *App) GetProfileLoadout(inspectionID string, steam64ID string, language string) (_ ProfileLoadout, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return ProfileLoadout{}, err
+	}
+	steamID, err := ParseSteamID(steam64ID)
+	if err != nil {
+		return ProfileLoadout{}, fmt.Errorf("%w: %w", ErrInvalidSteamID, err)
+	}
+	return GetProfileLoadout(ctx, steamID, a.languageFor(language))
+}
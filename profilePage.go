@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// AvatarURLs are the three sizes Steam renders an account avatar in
+type AvatarURLs struct {
+	Full   string `json:"full"`
+	Medium string `json:"medium"`
+	Small  string `json:"small"`
+}
+
+// ProfilePage is what a community profile page shows about its owner. The loadout is the
+// equipped items API result completed with whatever the page renders on top of it.
+type ProfilePage struct {
+	SteamID        SteamID         `json:"steam_id" ts_type:"string"`
+	PersonaName    string          `json:"persona_name"`
+	Level          int             `json:"level"`
+	CountryCode    string          `json:"country_code"`
+	CountryFlag    string          `json:"country_flag"`
+	Avatar         AvatarURLs      `json:"avatar"`
+	AvatarFrame    string          `json:"avatar_frame"`
+	AnimatedAvatar string          `json:"animated_avatar"`
+	Background     BackgroundMedia `json:"background"`
+	Loadout        ProfileLoadout  `json:"loadout"`
+}
+
+var avatarHashPattern = regexp.MustCompile(`/([0-9a-f]{40})(?:_full|_medium)?\.jpg$`)
+
+// avatarURLs derives every size from the URL of any one of them
+func avatarURLs(avatarURL string) AvatarURLs {
+	matches := avatarHashPattern.FindStringSubmatchIndex(avatarURL)
+	if matches == nil {
+		return AvatarURLs{Full: avatarURL}
+	}
+	base := avatarURL[:matches[3]]
+	return AvatarURLs{Full: base + "_full.jpg", Medium: base + "_medium.jpg", Small: base + ".jpg"}
+}
+
+// firstSrcset returns the first URL of a srcset attribute
+func firstSrcset(srcset string) string {
+	fields := strings.Fields(strings.Split(srcset, ",")[0])
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+func imageSource(img *html.Node) string {
+	if src := firstSrcset(htmlAttr(img, "srcset")); src != "" {
+		return src
+	}
+	return htmlAttr(img, "src")
+}
+
+// profilePageError reports the error Steam renders instead of a community page: an error box
+// for accounts that don't exist and a notice for private profiles. Private pages still carry
+// the header, so callers that only need the owner's details can ignore ErrPrivateProfile.
+func profilePageError(doc *html.Node) error {
+	if box := findNode(doc, func(n *html.Node) bool { return hasClass(n, "error_ctn") }); box != nil {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, nodeText(box))
+	}
+	if findNode(doc, func(n *html.Node) bool { return hasClass(n, "profile_private_info") }) != nil {
+		return ErrPrivateProfile
+	}
+	return nil
+}
+
+// parseProfilePage reads the profile owner's details from the HTML of their profile page
+func parseProfilePage(body []byte) (ProfilePage, error) {
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return ProfilePage{}, fmt.Errorf("failed to parse profile page: %w: %w", ErrMarkupChanged, err)
+	}
+	if err := profilePageError(doc); errors.Is(err, ErrProfileNotFound) {
+		return ProfilePage{}, err
+	}
+	var page ProfilePage
+	page.SteamID, _ = extractProfileSteamID(body)
+	page.Background = extractProfileBackground(doc)
+
+	if persona := findNode(doc, func(n *html.Node) bool { return hasClass(n, "actual_persona_name") }); persona != nil {
+		page.PersonaName = nodeText(persona)
+	}
+	if level := findNode(doc, func(n *html.Node) bool { return hasClass(n, "friendPlayerLevelNum") }); level != nil {
+		page.Level, _ = strconv.Atoi(nodeText(level))
+	}
+	if flag := findNode(doc, func(n *html.Node) bool { return hasClass(n, "profile_flag") }); flag != nil {
+		page.CountryFlag = htmlAttr(flag, "src")
+		page.CountryCode = strings.ToUpper(strings.TrimSuffix(path.Base(page.CountryFlag), path.Ext(page.CountryFlag)))
+	}
+
+	// The account avatar is announced in the head even when an animated avatar covers it
+	if link := findNode(doc, func(n *html.Node) bool { return isElement(n, "link") && htmlAttr(n, "rel") == "image_src" }); link != nil {
+		page.Avatar = avatarURLs(htmlAttr(link, "href"))
+	}
+	avatar := findNode(doc, func(n *html.Node) bool { return hasClass(n, "playerAvatar") && hasClass(n, "profile_header_size") })
+	if avatar == nil {
+		return page, nil
+	}
+	if frame := findNode(avatar, func(n *html.Node) bool { return hasClass(n, "profile_avatar_frame") }); frame != nil {
+		if img := findNode(frame, func(n *html.Node) bool { return isElement(n, "img") }); img != nil {
+			page.AvatarFrame = imageSource(img)
+		}
+	}
+	for _, img := range findNodes(avatar, func(n *html.Node) bool { return isElement(n, "img") }) {
+		if img.Parent != nil && hasClass(img.Parent, "profile_avatar_frame") {
+			continue
+		}
+		// Animated avatars are points shop items, account avatars live on the avatars CDN
+		source := imageSource(img)
+		if strings.Contains(source, "/images/items/") {
+			page.AnimatedAvatar = source
+		} else if page.Avatar.Full == "" {
+			page.Avatar = avatarURLs(source)
+		}
+	}
+	return page, nil
+}
+
+// mergeProfilePage fills the slots the equipped items API left empty with what the page shows.
+// Items from the API win when both have one, they carry the full item definition.
+func mergeProfilePage(loadout *ProfileLoadout, page ProfilePage) {
+	fill := func(class CommunityItemClass, imageURI string, animated bool) {
+		slot := loadout.Slot(class)
+		if *slot != nil || imageURI == "" {
+			return
+		}
+		appid, _ := strconv.Atoi(getAppIDGolang(imageURI))
+		*slot = &EquippedItem{
+			Appid:                  appid,
+			CommunityItemClass:     class,
+			CommunityItemClassName: class.String(),
+			Animated:               animated,
+			ItemImageURI:           imageURI,
+		}
+	}
+	fill(CommunityItemClassAvatarFrame, page.AvatarFrame, false)
+	fill(CommunityItemClassAnimatedAvatar, page.AnimatedAvatar, true)
+	backgroundURI := page.Background.Image
+	if page.Background.Mp4 != "" {
+		backgroundURI = page.Background.Mp4
+	} else if page.Background.Webm != "" {
+		backgroundURI = page.Background.Webm
+	}
+	fill(CommunityItemClassProfileBackground, backgroundURI, page.Background.Mp4 != "" || page.Background.Webm != "")
+}
+
+// GetProfilePage parses the profile page of steamID and merges it with the equipped items API.
+// The page alone is returned when the API fails, it is empty for some profiles and regions anyway.
+func GetProfilePage(ctx context.Context, steamID SteamID, language string) (ProfilePage, error) {
+	body, err := steamClient.GetBody(ctx, steamID.ProfileURL())
+	if err != nil {
+		return ProfilePage{}, err
+	}
+	page, err := parseProfilePage(body)
+	if err != nil {
+		return ProfilePage{}, err
+	}
+	if page.SteamID == 0 {
+		page.SteamID = steamID
+	}
+	page.Loadout, err = GetProfileLoadout(ctx, steamID, language)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ProfilePage{}, ctx.Err()
+		}
+		page.Loadout = ProfileLoadout{SteamID: steamID}
+	}
+	mergeProfilePage(&page.Loadout, page)
+	return page, nil
+}
+
+func (a *App) GetProfilePage(inspectionID string, steam64ID string, language string) (_ ProfilePage, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return ProfilePage{}, err
+	}
+	steamID, err := ParseSteamID(steam64ID)
+	if err != nil {
+		return ProfilePage{}, fmt.Errorf("%w: %w", ErrInvalidSteamID, err)
+	}
+	return GetProfilePage(ctx, steamID, a.languageFor(language))
+}
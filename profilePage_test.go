@@ -0,0 +1,18 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestParseProfilePage(t *testing.T) {
+	checkGoldenPages(t, "profiles", "*.html", func(t *testing.T, body []byte, doc *html.Node) any {
+		profile, err := parseProfilePage(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mergeProfilePage(&profile.Loadout, profile)
+		return profile
+	})
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CommunityProfileXML is the subset of steamcommunity.com/id/<name>/?xml=1 we care about
+type CommunityProfileXML struct {
+	SteamID64       SteamID   `xml:"steamID64" json:"steam_id64" ts_type:"string"`
+	PersonaName     string    `xml:"steamID" json:"persona_name"`
+	PrivacyState    string    `xml:"privacyState" json:"privacy_state"`
+	VisibilityState int       `xml:"visibilityState" json:"visibility_state"`
+	PrivacyMessage  string    `xml:"privacyMessage" json:"privacy_message"`
+	OnlineState     string    `xml:"onlineState" json:"online_state"`
+	InGameName      string    `xml:"inGameInfo>gameName" json:"in_game_name"`
+	AvatarIcon      string    `xml:"avatarIcon" json:"avatar_icon"`
+	AvatarMedium    string    `xml:"avatarMedium" json:"avatar_medium"`
+	AvatarFull      string    `xml:"avatarFull" json:"avatar_full"`
+	MemberSince     string    `xml:"memberSince" json:"member_since"`
+	MemberSinceTime time.Time `xml:"-" json:"member_since_time"`
+	Error           string    `xml:"error" json:"-"`
+}
+
+var memberSinceLayouts = []string{"January 2, 2006", "January 2"}
+
+func getCommunityProfileXML(ctx context.Context, profileURL string) (CommunityProfileXML, error) {
+	var profile CommunityProfileXML
+	body, err := steamClient.GetBody(ctx, strings.TrimSuffix(profileURL, "/")+"/?xml=1")
+	if err != nil {
+		return profile, err
+	}
+
+	// The root element is <profile> for existing accounts and <response> for errors
+	err = xml.Unmarshal(body, &profile)
+	if err != nil {
+		return profile, fmt.Errorf("failed to decode profile XML: %w: %w", ErrMarkupChanged, err)
+	}
+	if profile.Error != "" {
+		return profile, fmt.Errorf("%w: steam community: %s", ErrProfileNotFound, strings.TrimSpace(profile.Error))
+	}
+	if !profile.SteamID64.IsValid() {
+		return profile, fmt.Errorf("%w: profile XML has no valid steamID64", ErrMarkupChanged)
+	}
+	for _, layout := range memberSinceLayouts {
+		if t, err := time.Parse(layout, profile.MemberSince); err == nil {
+			if t.Year() == 0 {
+				t = t.AddDate(time.Now().Year(), 0, 0)
+			}
+			profile.MemberSinceTime = t
+			break
+		}
+	}
+	return profile, nil
+}
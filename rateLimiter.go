@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HostRateLimit configures the token bucket of one host. Host may carry a path prefix,
+// e.g. "steamcommunity.com/market" limits the market separately from profile pages.
+type HostRateLimit struct {
+	Host              string  `json:"host"`
+	RequestsPerMinute float64 `json:"requests_per_minute"`
+	Burst             int     `json:"burst"`
+}
+
+func defaultHostRateLimits() []HostRateLimit {
+	return []HostRateLimit{
+		{Host: "steamcommunity.com/market/priceoverview", RequestsPerMinute: 20, Burst: 5},
+		{Host: "steamcommunity.com/market", RequestsPerMinute: 30, Burst: 5},
+		{Host: "steamcommunity.com", RequestsPerMinute: 120, Burst: 10},
+		{Host: "store.steampowered.com", RequestsPerMinute: 40, Burst: 10},
+	}
+}
+
+// mergeDefaultHostRateLimits appends the default limit of every host limits has no entry for, so
+// buckets added in later versions reach settings saved before them. A default is turned off by
+// keeping its host with requests_per_minute set to 0.
+func mergeDefaultHostRateLimits(limits []HostRateLimit) []HostRateLimit {
+	configured := make(map[string]bool, len(limits))
+	for _, limit := range limits {
+		configured[rateLimitKey(limit.Host)] = true
+	}
+	merged := append([]HostRateLimit{}, limits...)
+	for _, limit := range defaultHostRateLimits() {
+		if !configured[rateLimitKey(limit.Host)] {
+			merged = append(merged, limit)
+		}
+	}
+	return merged
+}
+
+func rateLimitKey(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), "/")
+}
+
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+func newTokenBucket(limit HostRateLimit) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: limit.RequestsPerMinute / 60, burst: burst, tokens: burst, lastFill: time.Now()}
+}
+
+func (b *tokenBucket) sameLimit(other *tokenBucket) bool {
+	return b.rate == other.rate && b.burst == other.burst
+}
+
+// reserve takes a token and returns how long the caller has to wait before using it
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.tokens += now.Sub(b.lastFill).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.lastFill = now
+	b.tokens--
+	if b.tokens >= 0 || b.rate <= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// hostRateLimiter keeps one token bucket per configured host or host/path prefix
+type hostRateLimiter struct {
+	mu      sync.RWMutex
+	buckets map[string]*tokenBucket
+}
+
+func newHostRateLimiter(limits []HostRateLimit) *hostRateLimiter {
+	l := &hostRateLimiter{}
+	l.configure(limits)
+	return l
+}
+
+// configure replaces the limits. Buckets whose rate and burst didn't change are kept, with the
+// tokens they have left.
+func (l *hostRateLimiter) configure(limits []HostRateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	buckets := make(map[string]*tokenBucket, len(limits))
+	for _, limit := range limits {
+		key := rateLimitKey(limit.Host)
+		if key == "" || limit.RequestsPerMinute <= 0 {
+			continue
+		}
+		bucket := newTokenBucket(limit)
+		if old, ok := l.buckets[key]; ok && old.sameLimit(bucket) {
+			bucket = old
+		}
+		buckets[key] = bucket
+	}
+	l.buckets = buckets
+}
+
+// bucketFor returns the bucket with the longest key matching host+path
+func (l *hostRateLimiter) bucketFor(host string, path string) *tokenBucket {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	target := strings.ToLower(host) + path
+	var match *tokenBucket
+	matchLen := 0
+	for key, bucket := range l.buckets {
+		if len(key) <= matchLen || !strings.HasPrefix(target, key) {
+			continue
+		}
+		if len(target) > len(key) && target[len(key)] != '/' {
+			continue
+		}
+		match, matchLen = bucket, len(key)
+	}
+	return match
+}
+
+func (l *hostRateLimiter) wait(ctx context.Context, host string, path string) error {
+	bucket := l.bucketFor(host, path)
+	if bucket == nil {
+		return nil
+	}
+	delay := bucket.reserve()
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	return sleepContext(ctx, delay)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucketRefill(t *testing.T) {
+	bucket := newTokenBucket(HostRateLimit{RequestsPerMinute: 6000, Burst: 2})
+	for i := 0; i < 2; i++ {
+		if delay := bucket.reserve(); delay != 0 {
+			t.Fatalf("reservation %d within the burst waits %v", i, delay)
+		}
+	}
+	if delay := bucket.reserve(); delay <= 0 || delay > 10*time.Millisecond {
+		t.Errorf("reservation past the burst waits %v, want up to one interval of 10ms", delay)
+	}
+	time.Sleep(40 * time.Millisecond)
+	if delay := bucket.reserve(); delay != 0 {
+		t.Errorf("reservation after the refill waits %v", delay)
+	}
+	if delay := bucket.reserve(); delay != 0 {
+		t.Errorf("second reservation after the refill waits %v, the burst should have refilled", delay)
+	}
+	if delay := bucket.reserve(); delay <= 0 {
+		t.Error("refill went past the burst")
+	}
+}
+
+func TestHostRateLimiterBucketFor(t *testing.T) {
+	l := newHostRateLimiter(defaultHostRateLimits())
+	l.mu.RLock()
+	market, priceOverview, community := l.buckets["steamcommunity.com/market"], l.buckets["steamcommunity.com/market/priceoverview"], l.buckets["steamcommunity.com"]
+	l.mu.RUnlock()
+	tests := []struct {
+		host string
+		path string
+		want *tokenBucket
+	}{
+		{"steamcommunity.com", "/market/itemordershistogram", market},
+		{"steamcommunity.com", "/market/listings/753/x", market},
+		{"steamcommunity.com", "/market", market},
+		{"steamcommunity.com", "/market/priceoverview/", priceOverview},
+		{"SteamCommunity.com", "/market/priceoverview", priceOverview},
+		{"steamcommunity.com", "/marketplace", community},
+		{"steamcommunity.com", "/id/gabelogannewell", community},
+		{"steamcommunity.com", "", community},
+		{"api.steampowered.com", "/ISteamUser/GetPlayerSummaries/v2/", nil},
+		{"steamcommunity.com.evil", "/market", nil},
+	}
+	for _, test := range tests {
+		if got := l.bucketFor(test.host, test.path); got != test.want {
+			t.Errorf("bucketFor(%q, %q) picked the wrong bucket", test.host, test.path)
+		}
+	}
+}
+
+func TestHostRateLimiterConfigureKeepsUnchangedBuckets(t *testing.T) {
+	l := newHostRateLimiter([]HostRateLimit{
+		{Host: "steamcommunity.com", RequestsPerMinute: 60, Burst: 5},
+		{Host: "store.steampowered.com", RequestsPerMinute: 60, Burst: 5},
+	})
+	community, store := l.bucketFor("steamcommunity.com", "/"), l.bucketFor("store.steampowered.com", "/")
+	l.configure([]HostRateLimit{
+		{Host: "steamcommunity.com/", RequestsPerMinute: 60, Burst: 5},
+		{Host: "store.steampowered.com", RequestsPerMinute: 30, Burst: 5},
+		{Host: "api.steampowered.com", RequestsPerMinute: 0, Burst: 5},
+	})
+	if l.bucketFor("steamcommunity.com", "/") != community {
+		t.Error("the unchanged steamcommunity.com bucket was rebuilt")
+	}
+	if got := l.bucketFor("store.steampowered.com", "/"); got == store || got.rate != 0.5 {
+		t.Error("the changed store.steampowered.com bucket was kept")
+	}
+	if l.bucketFor("api.steampowered.com", "/") != nil {
+		t.Error("a limit of 0 requests per minute created a bucket")
+	}
+}
+
+func TestMergeDefaultHostRateLimits(t *testing.T) {
+	saved := []HostRateLimit{
+		{Host: "steamcommunity.com", RequestsPerMinute: 60, Burst: 3},
+		{Host: "SteamCommunity.com/Market/", RequestsPerMinute: 10, Burst: 1},
+		{Host: "store.steampowered.com", RequestsPerMinute: 0},
+		{Host: "example.com", RequestsPerMinute: 5, Burst: 1},
+	}
+	merged := mergeDefaultHostRateLimits(saved)
+	byHost := map[string]HostRateLimit{}
+	for _, limit := range merged {
+		if _, ok := byHost[rateLimitKey(limit.Host)]; ok {
+			t.Errorf("%s is listed twice", limit.Host)
+		}
+		byHost[rateLimitKey(limit.Host)] = limit
+	}
+	for _, limit := range saved {
+		if byHost[rateLimitKey(limit.Host)] != limit {
+			t.Errorf("saved limit of %s was replaced by %+v", limit.Host, byHost[rateLimitKey(limit.Host)])
+		}
+	}
+	if _, ok := byHost["steamcommunity.com/market/priceoverview"]; !ok {
+		t.Error("the missing default priceoverview bucket was not added")
+	}
+	if len(merged) != len(saved)+1 {
+		t.Errorf("merged %d limits, want the %d saved ones plus priceoverview", len(merged), len(saved))
+	}
+	if got := mergeDefaultHostRateLimits(nil); len(got) != len(defaultHostRateLimits()) {
+		t.Errorf("no saved limits merged into %d, want every default", len(got))
+	}
+	if got := normalizeSettings(AppSettings{HostRateLimits: saved}).HostRateLimits; len(got) != len(merged) {
+		t.Errorf("normalizeSettings kept %d limits, want %d", len(got), len(merged))
+	}
+}
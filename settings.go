@@ -7,9 +7,27 @@ import (
 )
 
 type AppSettings struct {
-	APIKey           string `json:"api_key"`
-	OpenLinksInSteam int    `json:"open_links_in_steam"`
-	SteamCurrency    int    `json:"steam_currency"`
+	APIKey           string          `json:"api_key"`
+	OpenLinksInSteam int             `json:"open_links_in_steam"`
+	SteamCurrency    int             `json:"steam_currency"`
+	HostRateLimits   []HostRateLimit `json:"host_rate_limits"`
+	MarketWorkers    int             `json:"market_workers"`
+	Language         string          `json:"language"`
+}
+
+const defaultMarketWorkers = 4
+
+// normalizeSettings replaces missing or out of range values with their defaults
+func normalizeSettings(settings AppSettings) AppSettings {
+	settings.HostRateLimits = mergeDefaultHostRateLimits(settings.HostRateLimits)
+	if settings.MarketWorkers < 1 || settings.MarketWorkers > 16 {
+		settings.MarketWorkers = defaultMarketWorkers
+	}
+	if validateCurrency(settings.SteamCurrency) != nil {
+		settings.SteamCurrency = defaultCurrency
+	}
+	settings.Language = normalizeLanguage(settings.Language)
+	return settings
 }
 
 func SaveSettings(settings AppSettings) error {
@@ -38,7 +56,10 @@ func LoadSettings() (AppSettings, error) {
 	var settings = AppSettings{
 		APIKey:           "",
 		OpenLinksInSteam: 2,
-		SteamCurrency:    1,
+		SteamCurrency:    defaultCurrency,
+		HostRateLimits:   defaultHostRateLimits(),
+		MarketWorkers:    defaultMarketWorkers,
+		Language:         defaultLanguage,
 	}
 	dir, err := getAppdataDir()
 	if err != nil {
@@ -54,18 +75,37 @@ func LoadSettings() (AppSettings, error) {
 		return settings, err
 	}
 
-	return settings, nil
+	return normalizeSettings(settings), nil
 }
 
-func (a *App) SaveAppSettings(settings AppSettings) error {
-	err := SaveSettings(settings)
-	return err
+func (a *App) SaveAppSettings(settings AppSettings) (err error) {
+	defer handleBindingError(&err)
+	if err := validateCurrency(settings.SteamCurrency); err != nil {
+		return err
+	}
+	settings = normalizeSettings(settings)
+	a.setSettings(settings)
+	return SaveSettings(settings)
 }
 
 func (a *App) GetSettings() AppSettings {
+	return a.currentSettings()
+}
+
+// currentSettings returns a snapshot of the settings, bindings and workers run concurrently with SaveAppSettings
+func (a *App) currentSettings() AppSettings {
+	a.settingsMu.RLock()
+	defer a.settingsMu.RUnlock()
 	return a.settings
 }
 
+func (a *App) setSettings(settings AppSettings) {
+	a.settingsMu.Lock()
+	a.settings = settings
+	a.settingsMu.Unlock()
+	steamClient.SetRateLimits(settings.HostRateLimits)
+}
+
 func getAppdataDir() (string, error) {
 	appdata, err := os.UserConfigDir()
 	if err != nil {
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type ShowcaseKind string
+
+const (
+	ShowcaseKindUnknown        ShowcaseKind = "unknown"
+	ShowcaseKindItems          ShowcaseKind = "items"
+	ShowcaseKindTrade          ShowcaseKind = "trade"
+	ShowcaseKindBadgeCollector ShowcaseKind = "badge_collector"
+	ShowcaseKindGames          ShowcaseKind = "games"
+	ShowcaseKindFavoriteGame   ShowcaseKind = "favorite_game"
+	ShowcaseKindArtwork        ShowcaseKind = "artwork"
+	ShowcaseKindScreenshots    ShowcaseKind = "screenshots"
+	ShowcaseKindWorkshop       ShowcaseKind = "workshop"
+	ShowcaseKindReview         ShowcaseKind = "review"
+	ShowcaseKindGuides         ShowcaseKind = "guides"
+	ShowcaseKindAchievements   ShowcaseKind = "achievements"
+	ShowcaseKindCustomInfo     ShowcaseKind = "custom_info"
+	ShowcaseKindGroup          ShowcaseKind = "group"
+)
+
+// showcaseMarkers are the classes Steam puts inside each kind of showcase. The trade showcase
+// reuses the item slots, so it has to be checked before the item showcase.
+var showcaseMarkers = []struct {
+	kind    ShowcaseKind
+	classes []string
+}{
+	{ShowcaseKindTrade, []string{"trade_showcase", "tradeoffer_showcase"}},
+	{ShowcaseKindItems, []string{"item_showcase", "showcase_item"}},
+	{ShowcaseKindBadgeCollector, []string{"badge_showcase", "badge_collector_showcase", "showcase_badge"}},
+	{ShowcaseKindFavoriteGame, []string{"favoritegame_showcase"}},
+	{ShowcaseKindGames, []string{"gamecollector_showcase", "game_collector_showcase"}},
+	{ShowcaseKindAchievements, []string{"achievement_showcase", "showcase_achievement"}},
+	{ShowcaseKindArtwork, []string{"artwork_showcase", "favoriteartwork_showcase"}},
+	{ShowcaseKindScreenshots, []string{"screenshot_showcase", "favoritescreenshot_showcase"}},
+	{ShowcaseKindWorkshop, []string{"myworkshop_showcase", "workshop_showcase", "favoriteworkshop_showcase"}},
+	{ShowcaseKindReview, []string{"review_showcase", "favoritereview_showcase"}},
+	{ShowcaseKindGuides, []string{"myguides_showcase", "guide_showcase", "favoriteguide_showcase"}},
+	{ShowcaseKindCustomInfo, []string{"customtext_showcase"}},
+	{ShowcaseKindGroup, []string{"favoritegroup_showcase"}},
+}
+
+// ShowcaseItem is an inventory item shown in a showcase slot, ids are strings like in the
+// economy API since asset ids don't fit into a JavaScript number
+type ShowcaseItem struct {
+	Appid      int    `json:"appid"`
+	ContextID  string `json:"context_id"`
+	AssetID    string `json:"asset_id"`
+	ClassID    string `json:"class_id"`
+	InstanceID string `json:"instance_id"`
+	Image      string `json:"image"`
+}
+
+type Showcase struct {
+	Kind             ShowcaseKind   `json:"kind"`
+	Title            string         `json:"title"`
+	Items            []ShowcaseItem `json:"items"`
+	Appids           []int          `json:"appids"`
+	PublishedFileIDs []string       `json:"published_file_ids"`
+	Images           []string       `json:"images"`
+	Text             string         `json:"text"`
+}
+
+var (
+	showcaseAppidPattern         = regexp.MustCompile(`(?:store\.steampowered\.com/app/|steamcommunity\.com/app/|/gamecards/|/stats/|/steam/apps/)(\d+)`)
+	showcasePublishedFilePattern = regexp.MustCompile(`/(?:sharedfiles|workshop)/filedetails/\?id=(\d+)`)
+	showcaseReviewPattern        = regexp.MustCompile(`/recommended/(\d+)`)
+)
+
+// parseEconomyItem reads data-economy-item, which is either "classinfo/appid/classid/instanceid"
+// or "appid/contextid/assetid/owner"
+func parseEconomyItem(value string) (ShowcaseItem, bool) {
+	parts := strings.Split(strings.Trim(value, "/"), "/")
+	var item ShowcaseItem
+	if len(parts) > 0 && parts[0] == "classinfo" {
+		parts = parts[1:]
+		if len(parts) < 2 {
+			return ShowcaseItem{}, false
+		}
+		item.ClassID = parts[1]
+		if len(parts) > 2 {
+			item.InstanceID = parts[2]
+		}
+	} else {
+		if len(parts) < 3 {
+			return ShowcaseItem{}, false
+		}
+		item.ContextID, item.AssetID = parts[1], parts[2]
+	}
+	appid, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return ShowcaseItem{}, false
+	}
+	item.Appid = appid
+	return item, true
+}
+
+func classifyShowcase(block *html.Node) ShowcaseKind {
+	for _, marker := range showcaseMarkers {
+		for _, class := range marker.classes {
+			if findNode(block, func(n *html.Node) bool { return hasClass(n, class) }) != nil {
+				return marker.kind
+			}
+		}
+	}
+	// Trade showcases without their own class still link to a new trade offer
+	if findNode(block, func(n *html.Node) bool {
+		return isElement(n, "a") && strings.Contains(htmlAttr(n, "href"), "/tradeoffer/new")
+	}) != nil {
+		return ShowcaseKindTrade
+	}
+	return ShowcaseKindUnknown
+}
+
+// appendUnique appends value unless it is already in values
+func appendUnique[T comparable](values []T, value T) []T {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func parseShowcase(block *html.Node) Showcase {
+	showcase := Showcase{
+		Kind:             classifyShowcase(block),
+		Items:            []ShowcaseItem{},
+		Appids:           []int{},
+		PublishedFileIDs: []string{},
+		Images:           []string{},
+	}
+	if header := findNode(block, func(n *html.Node) bool { return hasClass(n, "profile_customization_header") }); header != nil {
+		showcase.Title = nodeText(header)
+	}
+	if showcase.Kind == ShowcaseKindCustomInfo {
+		if notes := findNode(block, func(n *html.Node) bool { return hasClass(n, "showcase_notes") }); notes != nil {
+			showcase.Text = nodeText(notes)
+		}
+	}
+
+	for _, n := range findNodes(block, func(n *html.Node) bool { return n.Type == html.ElementNode }) {
+		if value := htmlAttr(n, "data-economy-item"); value != "" {
+			if item, ok := parseEconomyItem(value); ok {
+				if img := findNode(n, func(n *html.Node) bool { return isElement(n, "img") }); img != nil {
+					item.Image = imageSource(img)
+				}
+				showcase.Items = append(showcase.Items, item)
+				showcase.Appids = appendUnique(showcase.Appids, item.Appid)
+			}
+		}
+		for _, value := range []string{htmlAttr(n, "href"), htmlAttr(n, "src")} {
+			if matches := showcaseAppidPattern.FindStringSubmatch(value); len(matches) > 1 {
+				if appid, err := strconv.Atoi(matches[1]); err == nil {
+					showcase.Appids = appendUnique(showcase.Appids, appid)
+				}
+			}
+		}
+		href := htmlAttr(n, "href")
+		if matches := showcasePublishedFilePattern.FindStringSubmatch(href); len(matches) > 1 {
+			showcase.PublishedFileIDs = appendUnique(showcase.PublishedFileIDs, matches[1])
+		}
+		if matches := showcaseReviewPattern.FindStringSubmatch(href); len(matches) > 1 {
+			if appid, err := strconv.Atoi(matches[1]); err == nil {
+				showcase.Appids = appendUnique(showcase.Appids, appid)
+			}
+		}
+		if isElement(n, "img") {
+			if src := imageSource(n); src != "" {
+				showcase.Images = appendUnique(showcase.Images, src)
+			}
+		}
+		if matches := backgroundImageStylePattern.FindStringSubmatch(htmlAttr(n, "style")); len(matches) > 1 {
+			showcase.Images = appendUnique(showcase.Images, strings.TrimSpace(matches[1]))
+		}
+	}
+	return showcase
+}
+
+// parseShowcases returns the showcases of a profile page in the order the owner arranged them
+func parseShowcases(doc *html.Node) []Showcase {
+	showcases := []Showcase{}
+	for _, block := range findNodes(doc, func(n *html.Node) bool { return hasClass(n, "profile_customization") }) {
+		showcases = append(showcases, parseShowcase(block))
+	}
+	return showcases
+}
+
+func GetShowcases(ctx context.Context, steamID SteamID) ([]Showcase, error) {
+	body, err := steamClient.GetBody(ctx, steamID.ProfileURL())
+	if err != nil {
+		return nil, err
+	}
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse profile page: %w: %w", ErrMarkupChanged, err)
+	}
+	if err := profilePageError(doc); err != nil {
+		return nil, err
+	}
+	return parseShowcases(doc), nil
+}
+
+func (a *App) GetShowcases(inspectionID string, steam64ID string) (_ []Showcase, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return nil, err
+	}
+	steamID, err := ParseSteamID(steam64ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSteamID, err)
+	}
+	return GetShowcases(ctx, steamID)
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestParseShowcases(t *testing.T) {
+	checkGoldenPages(t, "showcases", "*.html", func(t *testing.T, body []byte, doc *html.Node) any {
+		return parseShowcases(doc)
+	})
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const steamClientUserAgent = "SteamProfileInspector/1.0 (+https://github.com/Levosilimo/SteamProfileInspector)"
+
+type SteamClientConfig struct {
+	Timeout     time.Duration
+	UserAgent   string
+	MaxRetries  int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	RateLimits  []HostRateLimit
+}
+
+func DefaultSteamClientConfig() SteamClientConfig {
+	return SteamClientConfig{
+		Timeout:     15 * time.Second,
+		UserAgent:   steamClientUserAgent,
+		MaxRetries:  3,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		RateLimits:  defaultHostRateLimits(),
+	}
+}
+
+// StatusError is returned by GetBody when Steam answers with a non-2xx status. URL has no
+// query, see redactURL.
+type StatusError struct {
+	StatusCode int
+	URL        string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request to %s failed with status %d", e.URL, e.StatusCode)
+}
+
+// Is makes a 429 that outlasted every retry match ErrRateLimited and a 5xx match ErrNetwork,
+// both are worth retrying later
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrNetwork:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// SteamClient is the HTTP client every fetcher talks to Steam through
+type SteamClient struct {
+	http    *http.Client
+	config  SteamClientConfig
+	limiter *hostRateLimiter
+}
+
+var steamClient = NewSteamClient(DefaultSteamClientConfig())
+
+func NewSteamClient(config SteamClientConfig) *SteamClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 8
+	return &SteamClient{
+		http:    &http.Client{Timeout: config.Timeout, Transport: transport},
+		config:  config,
+		limiter: newHostRateLimiter(config.RateLimits),
+	}
+}
+
+// SetRateLimits replaces the per-host limits, requests already waiting keep their old reservation
+func (c *SteamClient) SetRateLimits(limits []HostRateLimit) {
+	c.limiter.configure(limits)
+}
+
+// Get performs a GET request, retrying network errors, 5xx and 429 responses with jittered
+// exponential backoff. Non-retryable responses are returned as is, the caller closes the body.
+func (c *SteamClient) Get(ctx context.Context, rawURL string) (*http.Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", redactURLError(err))
+	}
+	for attempt := 0; ; attempt++ {
+		err = c.limiter.wait(ctx, u.Hostname(), u.Path)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", redactURLError(err))
+		}
+		req.Header.Set("User-Agent", c.config.UserAgent)
+
+		resp, err := c.http.Do(req)
+		err = redactURLError(err)
+		retryable := err != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		if !retryable {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if attempt >= c.config.MaxRetries {
+			if err != nil {
+				return nil, fmt.Errorf("failed to make request: %w: %w", ErrNetwork, err)
+			}
+			return resp, nil
+		}
+
+		delay := c.backoff(attempt)
+		if resp != nil {
+			// Retry-After is honored up to MaxBackoff, a server can't park a worker for longer
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+				if delay > c.config.MaxBackoff {
+					delay = c.config.MaxBackoff
+				}
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		err = sleepContext(ctx, delay)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// GetBody reads the whole response body and turns non-2xx statuses into a *StatusError
+func (c *SteamClient) GetBody(ctx context.Context, rawURL string) ([]byte, error) {
+	resp, err := c.Get(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &StatusError{StatusCode: resp.StatusCode, URL: redactURL(rawURL)}
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w: %w", ErrNetwork, err)
+	}
+	return body, nil
+}
+
+// redactURL drops the query of rawURL. Web API keys travel in the query and errors are shown
+// in the UI, so no error of the client carries one.
+func redactURL(rawURL string) string {
+	if i := strings.IndexAny(rawURL, "?#"); i >= 0 {
+		return rawURL[:i]
+	}
+	return rawURL
+}
+
+// redactURLError redacts the URL of the *url.Error net/http wraps request failures in
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactURL(urlErr.URL)
+	}
+	return err
+}
+
+func (c *SteamClient) backoff(attempt int) time.Duration {
+	delay := c.config.BaseBackoff << attempt
+	if delay <= 0 || delay > c.config.MaxBackoff {
+		delay = c.config.MaxBackoff
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter understands both delay-seconds and HTTP-date values
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		delay := time.Until(t)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// rewriteTransport sends every request to the test server, whatever Steam host it was built for
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (rt rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// useTestSteamClient points the global steamClient at handler until the test ends. The client
+// doesn't retry and has no rate limits.
+func useTestSteamClient(t *testing.T, handler http.Handler) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewSteamClient(SteamClientConfig{Timeout: 5 * time.Second, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	client.http.Transport = rewriteTransport{target: target}
+	previous := steamClient
+	steamClient = client
+	t.Cleanup(func() {
+		steamClient = previous
+		server.Close()
+	})
+}
+
+func TestSteamClientCapsRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewSteamClient(SteamClientConfig{
+		Timeout:     time.Second,
+		MaxRetries:  1,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	body, err := client.GetBody(ctx, server.URL)
+	if err != nil {
+		t.Fatalf("GetBody() = %v, want the retry to wait at most MaxBackoff", err)
+	}
+	if string(body) != "ok" || requests.Load() != 2 {
+		t.Errorf("GetBody() = %q after %d requests, want \"ok\" after 2", body, requests.Load())
+	}
+}
+
+func TestSteamClientErrorsOmitQuery(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	client := NewSteamClient(SteamClientConfig{Timeout: time.Second, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	for _, server := range []string{failing.URL, closed.URL} {
+		_, err := client.GetBody(context.Background(), server+"/ISteamUser/GetPlayerSummaries/v2/?key=SECRETKEY&steamids=1")
+		if err == nil || strings.Contains(err.Error(), "SECRETKEY") {
+			t.Errorf("GetBody() = %v, want an error without the key", err)
+		}
+	}
+
+	useTestSteamClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	_, err := getPlayerSummariesViaAPI(context.Background(), []SteamID{NewIndividualSteamID(22202)}, "SECRETKEY")
+	if err == nil {
+		t.Fatal("getPlayerSummariesViaAPI() succeeded against a failing server")
+	}
+	if message := newBindingError(err).Message; strings.Contains(message, "SECRETKEY") {
+		t.Errorf("binding error message %q carries the key", message)
+	}
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SteamID is a 64-bit Steam identifier:
+// universe (8 bits) | account type (4 bits) | instance (20 bits) | account id (32 bits)
+type SteamID uint64
+
+type SteamUniverse uint8
+
+const (
+	UniverseInvalid SteamUniverse = iota
+	UniversePublic
+	UniverseBeta
+	UniverseInternal
+	UniverseDev
+)
+
+type SteamAccountType uint8
+
+const (
+	AccountTypeInvalid SteamAccountType = iota
+	AccountTypeIndividual
+	AccountTypeMultiseat
+	AccountTypeGameServer
+	AccountTypeAnonGameServer
+	AccountTypePending
+	AccountTypeContentServer
+	AccountTypeClan
+	AccountTypeChat
+	AccountTypeConsoleUser
+	AccountTypeAnonUser
+)
+
+const (
+	InstanceAll     uint32 = 0
+	InstanceDesktop uint32 = 1
+	InstanceConsole uint32 = 2
+	InstanceWeb     uint32 = 4
+)
+
+const (
+	steamIDInstanceMask = 0x000FFFFF
+	chatInstanceClan    = (steamIDInstanceMask + 1) >> 1
+	chatInstanceLobby   = (steamIDInstanceMask + 1) >> 2
+	inviteCodeAlphabet  = "bcdfghjkmnpqrtvw"
+	inviteCodeHexDigits = "0123456789abcdef"
+)
+
+var steam3Letters = map[SteamAccountType]string{
+	AccountTypeInvalid:        "I",
+	AccountTypeIndividual:     "U",
+	AccountTypeMultiseat:      "M",
+	AccountTypeGameServer:     "G",
+	AccountTypeAnonGameServer: "A",
+	AccountTypePending:        "P",
+	AccountTypeContentServer:  "C",
+	AccountTypeClan:           "g",
+	AccountTypeChat:           "T",
+	AccountTypeAnonUser:       "a",
+}
+
+var (
+	steam64Regex      = regexp.MustCompile(`^\d{17,20}$`)
+	steam2Regex       = regexp.MustCompile(`^STEAM_([0-5]):([01]):(\d+)$`)
+	steam3Regex       = regexp.MustCompile(`^\[([IUMGAPCgTLcai]):([0-5]):(\d+)(?::(\d+))?]$`)
+	accountIDRegex    = regexp.MustCompile(`^\d{1,10}$`)
+	inviteCodeRegex   = regexp.MustCompile(`^[bcdfghjkmnpqrtvw]{1,8}(?:-[bcdfghjkmnpqrtvw]{1,8})?$`)
+	vanityNameRegex   = regexp.MustCompile(`^[A-Za-z\d_-]{2,32}$`)
+	profilePathRegex  = regexp.MustCompile(`^/profiles/(\d{17})/?`)
+	vanityPathRegex   = regexp.MustCompile(`^/id/([A-Za-z\d_-]{2,32})/?`)
+	invitePathRegex   = regexp.MustCompile(`^/p/([bcdfghjkmnpqrtvw-]+)/?`)
+	inviteCommunityRe = regexp.MustCompile(`^/user/([bcdfghjkmnpqrtvw-]+)/?`)
+)
+
+// NewSteamID assembles a SteamID from its components
+func NewSteamID(universe SteamUniverse, accountType SteamAccountType, instance uint32, accountID uint32) SteamID {
+	return SteamID(uint64(universe)<<56 | uint64(accountType)<<52 | uint64(instance&steamIDInstanceMask)<<32 | uint64(accountID))
+}
+
+// NewIndividualSteamID returns the SteamID of a regular public user account
+func NewIndividualSteamID(accountID uint32) SteamID {
+	return NewSteamID(UniversePublic, AccountTypeIndividual, InstanceDesktop, accountID)
+}
+
+func (id SteamID) Universe() SteamUniverse {
+	return SteamUniverse(uint64(id) >> 56)
+}
+
+func (id SteamID) AccountType() SteamAccountType {
+	return SteamAccountType((uint64(id) >> 52) & 0xF)
+}
+
+func (id SteamID) Instance() uint32 {
+	return uint32((uint64(id) >> 32) & steamIDInstanceMask)
+}
+
+func (id SteamID) AccountID() uint32 {
+	return uint32(id)
+}
+
+// IsValid reports whether universe, account type and instance form a SteamID Steam would issue
+func (id SteamID) IsValid() bool {
+	if id.Universe() <= UniverseInvalid || id.Universe() > UniverseDev {
+		return false
+	}
+	switch id.AccountType() {
+	case AccountTypeInvalid:
+		return false
+	case AccountTypeIndividual:
+		return id.AccountID() != 0 && id.Instance() <= InstanceWeb
+	case AccountTypeClan:
+		return id.AccountID() != 0 && id.Instance() == InstanceAll
+	case AccountTypeGameServer:
+		return id.AccountID() != 0
+	}
+	return id.AccountType() <= AccountTypeAnonUser
+}
+
+// IsIndividual reports whether the id belongs to a user account that can have a community profile
+func (id SteamID) IsIndividual() bool {
+	return id.AccountType() == AccountTypeIndividual && id.Universe() == UniversePublic
+}
+
+// Steam64 renders the id as a decimal SteamID64
+func (id SteamID) Steam64() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// Steam2 renders the legacy STEAM_X:Y:Z form. The public universe is written as 0, like Steam itself does.
+func (id SteamID) Steam2() string {
+	universe := id.Universe()
+	if universe == UniversePublic {
+		universe = UniverseInvalid
+	}
+	return fmt.Sprintf("STEAM_%d:%d:%d", universe, id.AccountID()&1, id.AccountID()>>1)
+}
+
+// Steam3 renders the [U:1:N] form. Types without a letter of their own are written as i, which
+// parseSteam3 reads back as AccountTypeConsoleUser, the only valid one of them.
+func (id SteamID) Steam3() string {
+	letter, ok := steam3Letters[id.AccountType()]
+	if !ok {
+		letter = "i"
+	}
+	if id.AccountType() == AccountTypeChat {
+		switch {
+		case id.Instance()&chatInstanceClan != 0:
+			letter = "c"
+		case id.Instance()&chatInstanceLobby != 0:
+			letter = "L"
+		}
+	}
+	needsInstance := id.AccountType() == AccountTypeAnonGameServer || id.AccountType() == AccountTypeMultiseat ||
+		(id.AccountType() == AccountTypeIndividual && id.Instance() != InstanceDesktop)
+	if needsInstance {
+		return fmt.Sprintf("[%s:%d:%d:%d]", letter, id.Universe(), id.AccountID(), id.Instance())
+	}
+	return fmt.Sprintf("[%s:%d:%d]", letter, id.Universe(), id.AccountID())
+}
+
+// InviteCode renders the friend code used by s.team/p/ links
+func (id SteamID) InviteCode() string {
+	hex := strconv.FormatUint(uint64(id.AccountID()), 16)
+	code := make([]byte, len(hex))
+	for i := 0; i < len(hex); i++ {
+		code[i] = inviteCodeAlphabet[strings.IndexByte(inviteCodeHexDigits, hex[i])]
+	}
+	if len(code) > 3 {
+		half := len(code) / 2
+		return string(code[:half]) + "-" + string(code[half:])
+	}
+	return string(code)
+}
+
+func (id SteamID) InviteURL() string {
+	return "https://s.team/p/" + id.InviteCode()
+}
+
+func (id SteamID) ProfileURL() string {
+	return "https://steamcommunity.com/profiles/" + id.Steam64()
+}
+
+func (id SteamID) String() string {
+	return id.Steam64()
+}
+
+// ParseSteamID accepts SteamID64, STEAM_X:Y:Z, [U:1:N], an invite code and /profiles/ or
+// s.team/p/ URLs. Vanity /id/ URLs can't be resolved offline; use ParseProfileInput for those.
+func ParseSteamID(input string) (SteamID, error) {
+	id, vanity, err := ParseProfileInput(input)
+	if err != nil {
+		return 0, err
+	}
+	if vanity != "" {
+		return 0, fmt.Errorf("%q is a vanity name and needs to be resolved online", vanity)
+	}
+	return id, nil
+}
+
+// ParseAccountID reads a bare account id, the N of [U:1:N], as a public individual SteamID.
+// ParseProfileInput can't tell such numbers from vanity names, callers that know the input is
+// an account id parse it here.
+func ParseAccountID(input string) (SteamID, error) {
+	input = strings.TrimSpace(input)
+	if !accountIDRegex.MatchString(input) {
+		return 0, fmt.Errorf("invalid account id %q", input)
+	}
+	accountID, err := strconv.ParseUint(input, 10, 32)
+	if err != nil || accountID == 0 {
+		return 0, fmt.Errorf("invalid account id %q", input)
+	}
+	return NewIndividualSteamID(uint32(accountID)), nil
+}
+
+// ParseProfileInput parses any supported SteamID form. If the input is a vanity name or an /id/ URL,
+// the returned id is zero and vanity holds the name to resolve. Numbers shorter than a SteamID64
+// are vanity names too, account ids are only read as [U:1:N] or through ParseAccountID.
+func ParseProfileInput(input string) (id SteamID, vanity string, err error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, "", fmt.Errorf("empty SteamID")
+	}
+
+	if strings.Contains(input, "/") {
+		return parseProfileURL(input)
+	}
+
+	switch {
+	case steam64Regex.MatchString(input):
+		id, err = parseSteam64(input)
+	case steam2Regex.MatchString(input):
+		id, err = parseSteam2(input)
+	case steam3Regex.MatchString(input):
+		id, err = parseSteam3(input)
+	case inviteCodeRegex.MatchString(input) && strings.Contains(input, "-"):
+		id, err = parseInviteCode(input)
+	case vanityNameRegex.MatchString(input):
+		return 0, input, nil
+	default:
+		return 0, "", fmt.Errorf("%q is neither a SteamID nor a vanity name", input)
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	if !id.IsValid() {
+		return 0, "", fmt.Errorf("invalid SteamID %q", input)
+	}
+	return id, "", nil
+}
+
+func parseProfileURL(input string) (SteamID, string, error) {
+	if !strings.Contains(input, "://") {
+		input = "https://" + input
+	}
+	u, err := url.Parse(input)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to parse profile URL: %w", err)
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	switch host {
+	case "steamcommunity.com":
+		if m := profilePathRegex.FindStringSubmatch(u.Path); m != nil {
+			id, err := parseSteam64(m[1])
+			if err != nil {
+				return 0, "", err
+			}
+			if !id.IsValid() {
+				return 0, "", fmt.Errorf("invalid SteamID %q", m[1])
+			}
+			return id, "", nil
+		}
+		if m := vanityPathRegex.FindStringSubmatch(u.Path); m != nil {
+			return 0, m[1], nil
+		}
+		if m := inviteCommunityRe.FindStringSubmatch(u.Path); m != nil {
+			id, err := parseInviteCode(m[1])
+			return id, "", err
+		}
+	case "s.team":
+		if m := invitePathRegex.FindStringSubmatch(u.Path); m != nil {
+			id, err := parseInviteCode(m[1])
+			return id, "", err
+		}
+	}
+	return 0, "", fmt.Errorf("unsupported profile URL %q", input)
+}
+
+func parseSteam64(input string) (SteamID, error) {
+	value, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid SteamID64 %q: %w", input, err)
+	}
+	return SteamID(value), nil
+}
+
+func parseSteam2(input string) (SteamID, error) {
+	m := steam2Regex.FindStringSubmatch(input)
+	universe, _ := strconv.Atoi(m[1])
+	if universe == int(UniverseInvalid) {
+		universe = int(UniversePublic)
+	}
+	lowBit, _ := strconv.ParseUint(m[2], 10, 32)
+	highBits, err := strconv.ParseUint(m[3], 10, 31)
+	if err != nil {
+		return 0, fmt.Errorf("invalid STEAM_ id %q: %w", input, err)
+	}
+	return NewSteamID(SteamUniverse(universe), AccountTypeIndividual, InstanceDesktop, uint32(highBits<<1|lowBit)), nil
+}
+
+func parseSteam3(input string) (SteamID, error) {
+	m := steam3Regex.FindStringSubmatch(input)
+	universe, _ := strconv.Atoi(m[2])
+	accountID, err := strconv.ParseUint(m[3], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid [U:1:N] id %q: %w", input, err)
+	}
+	var instance uint32
+	if m[4] != "" {
+		value, err := strconv.ParseUint(m[4], 10, 20)
+		if err != nil {
+			return 0, fmt.Errorf("invalid instance in %q: %w", input, err)
+		}
+		instance = uint32(value)
+	}
+
+	var accountType SteamAccountType
+	switch letter := m[1]; letter {
+	case "c":
+		accountType, instance = AccountTypeChat, instance|chatInstanceClan
+	case "L":
+		accountType, instance = AccountTypeChat, instance|chatInstanceLobby
+	case "U":
+		accountType = AccountTypeIndividual
+		if m[4] == "" {
+			instance = InstanceDesktop
+		}
+	case "i":
+		accountType = AccountTypeConsoleUser
+	default:
+		for t, l := range steam3Letters {
+			if l == letter {
+				accountType = t
+				break
+			}
+		}
+	}
+	return NewSteamID(SteamUniverse(universe), accountType, instance, uint32(accountID)), nil
+}
+
+func parseInviteCode(input string) (SteamID, error) {
+	code := strings.ReplaceAll(input, "-", "")
+	hex := make([]byte, len(code))
+	for i := 0; i < len(code); i++ {
+		pos := strings.IndexByte(inviteCodeAlphabet, code[i])
+		if pos < 0 {
+			return 0, fmt.Errorf("invalid invite code %q", input)
+		}
+		hex[i] = inviteCodeHexDigits[pos]
+	}
+	accountID, err := strconv.ParseUint(string(hex), 16, 32)
+	if err != nil || accountID == 0 {
+		return 0, fmt.Errorf("invalid invite code %q", input)
+	}
+	return NewIndividualSteamID(uint32(accountID)), nil
+}
+
+// MarshalText keeps SteamID64 values intact in JSON, where JavaScript numbers would round them
+func (id SteamID) MarshalText() ([]byte, error) {
+	return []byte(id.Steam64()), nil
+}
+
+func (id *SteamID) UnmarshalText(text []byte) error {
+	parsed, err := ParseSteamID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
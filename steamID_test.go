@@ -0,0 +1,150 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseProfileInput(t *testing.T) {
+	const gabe SteamID = 76561197960287930
+	tests := []struct {
+		input   string
+		want    SteamID
+		vanity  string
+		wantErr bool
+	}{
+		{input: "76561197960287930", want: gabe},
+		{input: "  76561197960287930\n", want: gabe},
+		{input: "STEAM_0:0:11101", want: gabe},
+		{input: "STEAM_1:0:11101", want: gabe},
+		{input: "[U:1:22202]", want: gabe},
+		{input: "[U:1:22202:1]", want: gabe},
+		{input: "[g:1:4]", want: 103582791429521412},
+		{input: "hj-qp", want: gabe},
+		{input: "https://steamcommunity.com/profiles/76561197960287930/", want: gabe},
+		{input: "steamcommunity.com/profiles/76561197960287930", want: gabe},
+		{input: "https://s.team/p/hj-qp", want: gabe},
+		{input: "https://steamcommunity.com/user/hj-qp/", want: gabe},
+		{input: "http://www.steamcommunity.com/id/gabelogannewell/", vanity: "gabelogannewell"},
+		{input: "gabelogannewell", vanity: "gabelogannewell"},
+		{input: "1337", vanity: "1337"},
+		{input: "22202", vanity: "22202"},
+		{input: "some_name-2", vanity: "some_name-2"},
+
+		{input: "", wantErr: true},
+		{input: "   ", wantErr: true},
+		{input: "a", wantErr: true},
+		{input: "Levo Silimo", wantErr: true},
+		{input: "76561197960265728", wantErr: true},
+		{input: "999999999999999999999", vanity: "999999999999999999999"},
+		{input: "99999999999999999999", wantErr: true},
+		{input: "STEAM_0:2:1", wantErr: true},
+		{input: "STEAM_0:0:99999999999", wantErr: true},
+		{input: "[U:9:1]", wantErr: true},
+		{input: "[X:1:1]", wantErr: true},
+		{input: "[I:1:5]", wantErr: true},
+		{input: "[U:1:99999999999]", wantErr: true},
+		{input: "https://example.com/profiles/76561197960287930", wantErr: true},
+		{input: "https://steamcommunity.com/groups/valve", wantErr: true},
+		{input: "https://steamcommunity.com/profiles/76561197960265728", wantErr: true},
+		{input: "https://s.team/p/", wantErr: true},
+	}
+	for _, test := range tests {
+		id, vanity, err := ParseProfileInput(test.input)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("ParseProfileInput(%q) = %d, %q, want an error", test.input, id, vanity)
+			}
+			continue
+		}
+		if err != nil || id != test.want || vanity != test.vanity {
+			t.Errorf("ParseProfileInput(%q) = %d, %q, %v, want %d, %q", test.input, id, vanity, err, test.want, test.vanity)
+		}
+	}
+}
+
+func TestParseAccountID(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    SteamID
+		wantErr bool
+	}{
+		{input: "22202", want: 76561197960287930},
+		{input: " 22202\n", want: 76561197960287930},
+		{input: "1", want: 76561197960265729},
+		{input: "4294967295", want: 76561202255233023},
+		{input: "", wantErr: true},
+		{input: "0", wantErr: true},
+		{input: "4294967296", wantErr: true},
+		{input: "-1", wantErr: true},
+		{input: "+22202", wantErr: true},
+		{input: "[U:1:22202]", wantErr: true},
+		{input: "76561197960287930", wantErr: true},
+		{input: "gabe", wantErr: true},
+	}
+	for _, test := range tests {
+		id, err := ParseAccountID(test.input)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("ParseAccountID(%q) = %d, want an error", test.input, id)
+			}
+			continue
+		}
+		if err != nil || id != test.want {
+			t.Errorf("ParseAccountID(%q) = %d, %v, want %d", test.input, id, err, test.want)
+		}
+	}
+}
+
+func TestSteamIDRender(t *testing.T) {
+	const gabe SteamID = 76561197960287930
+	if got := gabe.Steam2(); got != "STEAM_0:0:11101" {
+		t.Errorf("Steam2() = %q", got)
+	}
+	if got := gabe.Steam3(); got != "[U:1:22202]" {
+		t.Errorf("Steam3() = %q", got)
+	}
+	if got := gabe.InviteURL(); got != "https://s.team/p/hj-qp" {
+		t.Errorf("InviteURL() = %q", got)
+	}
+	if got := gabe.ProfileURL(); got != "https://steamcommunity.com/profiles/76561197960287930" {
+		t.Errorf("ProfileURL() = %q", got)
+	}
+}
+
+func TestSteamIDRoundTrip(t *testing.T) {
+	ids := []SteamID{
+		NewIndividualSteamID(22202),
+		NewSteamID(UniversePublic, AccountTypeIndividual, InstanceWeb, 5),
+		NewSteamID(UniverseBeta, AccountTypeIndividual, InstanceDesktop, 5),
+		NewSteamID(UniversePublic, AccountTypeMultiseat, 3, 5),
+		NewSteamID(UniversePublic, AccountTypeGameServer, InstanceAll, 5),
+		NewSteamID(UniversePublic, AccountTypeAnonGameServer, 1234, 5),
+		NewSteamID(UniversePublic, AccountTypePending, InstanceAll, 5),
+		NewSteamID(UniversePublic, AccountTypeContentServer, InstanceAll, 5),
+		NewSteamID(UniversePublic, AccountTypeClan, InstanceAll, 5),
+		NewSteamID(UniversePublic, AccountTypeChat, chatInstanceClan, 5),
+		NewSteamID(UniversePublic, AccountTypeChat, chatInstanceLobby, 5),
+		NewSteamID(UniversePublic, AccountTypeConsoleUser, InstanceAll, 5),
+		NewSteamID(UniversePublic, AccountTypeAnonUser, InstanceAll, 5),
+	}
+	for _, id := range ids {
+		forms := []string{id.Steam64(), id.Steam3()}
+		// invite codes only carry public accounts, and need a dash to tell them from vanity names
+		if id.IsIndividual() && id.Instance() == InstanceDesktop && strings.Contains(id.InviteCode(), "-") {
+			forms = append(forms, id.Steam2(), id.InviteCode(), id.InviteURL(), id.ProfileURL())
+		}
+		if id.IsIndividual() && id.Instance() == InstanceDesktop {
+			if got, err := ParseAccountID(strconv.FormatUint(uint64(id.AccountID()), 10)); err != nil || got != id {
+				t.Errorf("ParseAccountID(%d) = %d, %v, want %d", id.AccountID(), got, err, id)
+			}
+		}
+		for _, form := range forms {
+			got, vanity, err := ParseProfileInput(form)
+			if err != nil || got != id || vanity != "" {
+				t.Errorf("ParseProfileInput(%q) = %d, %q, %v, want %d", form, got, vanity, err, id)
+			}
+		}
+	}
+}
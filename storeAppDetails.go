@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// appDetailsBatchSize is how many appids are sent in one filtered appdetails request
+const appDetailsBatchSize = 100
+
+// AppDetails is the part of the store appdetails response the app shows
+type AppDetails struct {
+	SteamAppID    int               `json:"steam_appid"`
+	Type          string            `json:"type"`
+	Name          string            `json:"name"`
+	IsFree        bool              `json:"is_free"`
+	HeaderImage   string            `json:"header_image"`
+	Developers    []string          `json:"developers"`
+	Publishers    []string          `json:"publishers"`
+	ReleaseDate   AppReleaseDate    `json:"release_date"`
+	PriceOverview *AppPriceOverview `json:"price_overview"`
+	Platforms     AppPlatforms      `json:"platforms"`
+	Categories    []AppCategory     `json:"categories"`
+}
+
+type AppReleaseDate struct {
+	ComingSoon bool   `json:"coming_soon"`
+	Date       string `json:"date"`
+}
+
+// AppPriceOverview holds prices in minor units of Currency
+type AppPriceOverview struct {
+	Currency         string `json:"currency"`
+	Initial          int    `json:"initial"`
+	Final            int    `json:"final"`
+	DiscountPercent  int    `json:"discount_percent"`
+	InitialFormatted string `json:"initial_formatted"`
+	FinalFormatted   string `json:"final_formatted"`
+}
+
+type AppPlatforms struct {
+	Windows bool `json:"windows"`
+	Mac     bool `json:"mac"`
+	Linux   bool `json:"linux"`
+}
+
+type AppCategory struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+}
+
+// AppDetailsOptions are the regional parameters of the store API. The country code decides
+// the currency of the price overview, the language the localized strings.
+type AppDetailsOptions struct {
+	CountryCode string
+	Language    string
+}
+
+func (o AppDetailsOptions) cacheKey(appid int) string {
+	return strconv.Itoa(appid) + ":" + o.Language + ":" + o.CountryCode
+}
+
+// appDetailsEnvelope is the per-app wrapper of appdetails. Data is an empty array instead of
+// an object when a filter matched nothing, so it is decoded lazily.
+type appDetailsEnvelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (e appDetailsEnvelope) hasData() bool {
+	data := bytes.TrimSpace(e.Data)
+	return e.Success && len(data) > 0 && data[0] == '{'
+}
+
+func appDetailsURL(appids []int, filters string, options AppDetailsOptions) string {
+	ids := make([]string, len(appids))
+	for i, appid := range appids {
+		ids[i] = strconv.Itoa(appid)
+	}
+	query := url.Values{}
+	query.Set("appids", strings.Join(ids, ","))
+	if filters != "" {
+		query.Set("filters", filters)
+	}
+	if options.CountryCode != "" {
+		query.Set("cc", options.CountryCode)
+	}
+	if options.Language != "" {
+		query.Set("l", options.Language)
+	}
+	return "https://store.steampowered.com/api/appdetails?" + query.Encode()
+}
+
+func fetchAppDetailsEnvelopes(ctx context.Context, appids []int, filters string, options AppDetailsOptions) (map[string]appDetailsEnvelope, error) {
+	body, err := steamClient.GetBody(ctx, appDetailsURL(appids, filters, options))
+	if err != nil {
+		return nil, err
+	}
+	var response map[string]appDetailsEnvelope
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse app details: %w: %w", ErrMarkupChanged, err)
+	}
+	return response, nil
+}
+
+// getAppDetails fetches the full store details of one app. The store only accepts a single
+// appid when no filter is given. ok is false when the store has no page for the app.
+func getAppDetails(ctx context.Context, appid int, options AppDetailsOptions) (details AppDetails, ok bool, err error) {
+	response, err := fetchAppDetailsEnvelopes(ctx, []int{appid}, "", options)
+	if err != nil {
+		return AppDetails{}, false, err
+	}
+	envelope, found := response[strconv.Itoa(appid)]
+	if !found || !envelope.hasData() {
+		return AppDetails{}, false, nil
+	}
+	if err := json.Unmarshal(envelope.Data, &details); err != nil {
+		return AppDetails{}, false, fmt.Errorf("failed to parse app details of %d: %w: %w", appid, ErrMarkupChanged, err)
+	}
+	return details, true, nil
+}
+
+// getAppPriceOverviews fetches the price overview of many apps with filters=price_overview,
+// the only filter for which the store accepts several appids. Free and unknown apps are left out.
+func getAppPriceOverviews(ctx context.Context, appids []int, options AppDetailsOptions) (map[int]AppPriceOverview, error) {
+	prices := make(map[int]AppPriceOverview)
+	for start := 0; start < len(appids); start += appDetailsBatchSize {
+		end := start + appDetailsBatchSize
+		if end > len(appids) {
+			end = len(appids)
+		}
+		response, err := fetchAppDetailsEnvelopes(ctx, appids[start:end], "price_overview", options)
+		if err != nil {
+			return nil, err
+		}
+		for id, envelope := range response {
+			if !envelope.hasData() {
+				continue
+			}
+			appid, err := strconv.Atoi(id)
+			if err != nil {
+				continue
+			}
+			var data struct {
+				PriceOverview *AppPriceOverview `json:"price_overview"`
+			}
+			if err := json.Unmarshal(envelope.Data, &data); err != nil {
+				return nil, fmt.Errorf("failed to parse price overview of %d: %w: %w", appid, ErrMarkupChanged, err)
+			}
+			if data.PriceOverview != nil {
+				prices[appid] = *data.PriceOverview
+			}
+		}
+	}
+	return prices, nil
+}
+
+// getCachedAppDetails wraps getAppDetails with the session cache, apps without a store page
+// are cached negatively
+func getCachedAppDetails(ctx context.Context, appid int, options AppDetailsOptions, cache *Cache) (AppDetails, bool, error) {
+	key := options.cacheKey(appid)
+	cachedResult, status := cache.games.Get(key)
+	switch status {
+	case CacheHit:
+		return cachedResult, true, nil
+	case CacheNegativeHit:
+		return AppDetails{}, false, nil
+	}
+	details, ok, err := getAppDetails(ctx, appid, options)
+	if err != nil {
+		return AppDetails{}, false, err
+	}
+	if !ok {
+		cache.games.SetNegative(key, 7*time.Minute)
+		return AppDetails{}, false, nil
+	}
+	cache.games.Set(key, details, 32*time.Minute)
+	cache.disk.Set(DiskCacheAppName, strconv.Itoa(appid)+":"+options.Language, details.Name)
+	return details, true, nil
+}
+
+func (a *App) GetAppDetails(inspectionID string, appid int, countryCode string) (_ AppDetails, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return AppDetails{}, err
+	}
+	details, _, err := getCachedAppDetails(ctx, appid, a.appDetailsOptions(countryCode), a.cache)
+	return details, err
+}
+
+func (a *App) GetAppPrices(inspectionID string, appids []int, countryCode string) (_ map[int]AppPriceOverview, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return nil, err
+	}
+	return getAppPriceOverviews(ctx, appids, a.appDetailsOptions(countryCode))
+}
+
+func (a *App) appDetailsOptions(countryCode string) AppDetailsOptions {
+	return AppDetailsOptions{
+		CountryCode: strings.ToUpper(strings.TrimSpace(countryCode)),
+		Language:    a.languageFor(""),
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestCache builds a Cache whose disk cache lives in a temporary directory and whose catalog
+// knows names
+func newTestCache(t *testing.T, names map[int]string) *Cache {
+	t.Helper()
+	disk, err := loadDiskCache(filepath.Join(t.TempDir(), "cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps := &AppCatalog{}
+	var file appCatalogFile
+	for appid, name := range names {
+		file.Apps = append(file.Apps, AppCatalogEntry{Appid: appid, Name: name})
+	}
+	apps.replace(file)
+	return &Cache{
+		backgrounds:    NewTTLCache[string, ProfileBackground](8),
+		games:          NewTTLCache[string, AppDetails](8),
+		priceHistories: NewTTLCache[string, PriceHistory](8),
+		orderBooks:     NewTTLCache[string, OrderBook](8),
+		disk:           disk,
+		apps:           apps,
+		stop:           make(chan struct{}),
+	}
+}
+
+// serveTestdata answers every request with the file at path
+func serveTestdata(t *testing.T, path string) http.Handler {
+	t.Helper()
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	})
+}
+
+func TestGetAppDetails(t *testing.T) {
+	tests := []struct {
+		fixture string
+		appid   int
+		wantOK  bool
+	}{
+		{"appdetails_2861720", 2861720, true},
+		// region-locked and removed apps answer with an empty array for data
+		{"appdetails_removed", 1151340, false},
+		{"appdetails_unknown", 999999999, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			useTestSteamClient(t, serveTestdata(t, filepath.Join("testdata", "store", tt.fixture+".json")))
+			details, ok, err := getAppDetails(context.Background(), tt.appid, AppDetailsOptions{Language: "english"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("getAppDetails(%d) ok = %v, want %v", tt.appid, ok, tt.wantOK)
+			}
+			if ok {
+				checkGolden(t, "store", tt.fixture, details)
+			}
+		})
+	}
+}
+
+func TestGetAppPriceOverviewsBatches(t *testing.T) {
+	var batches []int
+	useTestSteamClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if filters := r.URL.Query().Get("filters"); filters != "price_overview" {
+			t.Errorf("filters = %q, want price_overview", filters)
+		}
+		ids := strings.Split(r.URL.Query().Get("appids"), ",")
+		batches = append(batches, len(ids))
+		response := make(map[string]any, len(ids))
+		for _, id := range ids {
+			appid, _ := strconv.Atoi(id)
+			switch appid % 3 {
+			case 0:
+				// free apps match no filter
+				response[id] = map[string]any{"success": true, "data": []any{}}
+			case 1:
+				response[id] = map[string]any{"success": true, "data": map[string]any{
+					"price_overview": map[string]any{"currency": "USD", "initial": appid * 100, "final": appid * 100},
+				}}
+			default:
+				response[id] = map[string]any{"success": false}
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+
+	appids := make([]int, 150)
+	for i := range appids {
+		appids[i] = i + 1
+	}
+	prices, err := getAppPriceOverviews(context.Background(), appids, AppDetailsOptions{CountryCode: "US"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(batches) != "[100 50]" {
+		t.Errorf("batch sizes = %v, want [100 50]", batches)
+	}
+	if len(prices) != 50 {
+		t.Errorf("got %d prices, want 50", len(prices))
+	}
+	for appid, price := range prices {
+		if appid%3 != 1 || price.Final != appid*100 {
+			t.Errorf("price of %d = %+v", appid, price)
+		}
+	}
+}
+
+func TestGetGameName(t *testing.T) {
+	options := AppDetailsOptions{CountryCode: "US", Language: defaultLanguage}
+	cache := newTestCache(t, map[int]string{730: "Counter-Strike 2", 2861720: "Steam Winter Sale 2023"})
+	cache.games.Set(options.cacheKey(2861720), AppDetails{SteamAppID: 2861720, Name: "Steam Winter Sale 2023", IsFree: true}, time.Hour)
+	useTestSteamClient(t, serveTestdata(t, filepath.Join("testdata", "store", "appdetails_unknown.json")))
+
+	// a catalog name alone carries no store details
+	name, err := getGameName(context.Background(), 730, options, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name.SteamAppID != 730 || name.Name != "Counter-Strike 2" || name.Details != nil {
+		t.Errorf("getGameName(730) = %+v, want the catalog name without details", name)
+	}
+
+	name, err = getGameName(context.Background(), 2861720, options, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name.Details == nil || !name.Details.IsFree {
+		t.Errorf("getGameName(2861720) = %+v, want the cached details", name)
+	}
+
+	name, err = getGameName(context.Background(), 999999999, options, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != (GameName{}) {
+		t.Errorf("getGameName(999999999) = %+v, want a zero GameName", name)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ResolveStrategyWebAPI       = "web_api"
+	ResolveStrategyCommunityXML = "community_xml"
+	ResolveStrategySteamIDXYZ   = "steamid_xyz"
+	ResolveStrategyCache        = "cache"
+)
+
+// VanityResolution is the outcome of resolving a /id/<name> vanity URL
+type VanityResolution struct {
+	Vanity   string               `json:"vanity"`
+	SteamID  SteamID              `json:"steam_id" ts_type:"string"`
+	Strategy string               `json:"strategy"`
+	Profile  *CommunityProfileXML `json:"profile,omitempty"`
+}
+
+type vanityResolveStrategy struct {
+	name    string
+	resolve func(ctx context.Context, vanity string) (VanityResolution, error)
+}
+
+func vanityResolveStrategies(key string) []vanityResolveStrategy {
+	var strategies []vanityResolveStrategy
+	if key != "" {
+		strategies = append(strategies, vanityResolveStrategy{ResolveStrategyWebAPI, func(ctx context.Context, vanity string) (VanityResolution, error) {
+			steam64ID, err := getSteam64IDViaAPI(ctx, vanity, key)
+			if err != nil {
+				return VanityResolution{}, err
+			}
+			steamID, err := ParseSteamID(steam64ID)
+			return VanityResolution{SteamID: steamID}, err
+		}})
+	}
+	strategies = append(strategies,
+		vanityResolveStrategy{ResolveStrategyCommunityXML, func(ctx context.Context, vanity string) (VanityResolution, error) {
+			profile, err := getCommunityProfileXML(ctx, "https://steamcommunity.com/id/"+vanity)
+			if err != nil {
+				return VanityResolution{}, err
+			}
+			return VanityResolution{SteamID: profile.SteamID64, Profile: &profile}, nil
+		}},
+		vanityResolveStrategy{ResolveStrategySteamIDXYZ, func(ctx context.Context, vanity string) (VanityResolution, error) {
+			steamID, err := getSteamIDViaScrapper(ctx, vanity)
+			return VanityResolution{SteamID: steamID}, err
+		}},
+	)
+	return strategies
+}
+
+// resolveVanity tries every strategy in order and returns the first success. ErrInvalidAPIKey
+// stops the chain, the frontend asks the user for a new key when it sees it.
+func resolveVanity(ctx context.Context, vanity string, key string, cache *Cache) (VanityResolution, error) {
+	cacheKey := strings.ToLower(vanity)
+	if steam64ID, status := cache.disk.Get(DiskCacheVanity, cacheKey); status == CacheHit {
+		if steamID, err := ParseSteamID(steam64ID); err == nil {
+			return VanityResolution{Vanity: vanity, SteamID: steamID, Strategy: ResolveStrategyCache}, nil
+		}
+	}
+	var errs []error
+	for _, strategy := range vanityResolveStrategies(key) {
+		resolution, err := strategy.resolve(ctx, vanity)
+		if errors.Is(err, ErrInvalidAPIKey) {
+			return VanityResolution{}, err
+		}
+		if ctx.Err() != nil {
+			return VanityResolution{}, ctx.Err()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", strategy.name, err))
+			continue
+		}
+		resolution.Vanity = vanity
+		resolution.Strategy = strategy.name
+		cache.disk.Set(DiskCacheVanity, cacheKey, resolution.SteamID.Steam64())
+		return resolution, nil
+	}
+	return VanityResolution{}, fmt.Errorf("failed to resolve %s: %w", vanity, errors.Join(errs...))
+}
+
+func (a *App) ResolveVanityURL(inspectionID string, vanity string, key string) (_ VanityResolution, err error) {
+	defer handleBindingError(&err)
+	ctx, err := a.contextFor(inspectionID)
+	if err != nil {
+		return VanityResolution{}, err
+	}
+	_, name, err := ParseProfileInput(vanity)
+	if err != nil {
+		return VanityResolution{}, fmt.Errorf("%w: %w", ErrInvalidSteamID, err)
+	}
+	if name == "" {
+		return VanityResolution{}, fmt.Errorf("%w: %q is not a vanity name", ErrInvalidSteamID, vanity)
+	}
+	return resolveVanity(ctx, name, key, a.cache)
+}